@@ -0,0 +1,16 @@
+# Return an ECS service to its schedule.
+# Invoke with: terraform apply -invoke=action.sss_clear_override.api
+action "sss_clear_override" "api" {
+  config {
+    scalable_type = "ecs"
+    scalable_id   = sss_ecs_scaling.api.service_id
+    region        = "eu-west-1"
+  }
+}
+
+# Return every scalable in a group to its schedule.
+action "sss_clear_override" "live_sports" {
+  config {
+    group = "live-sports"
+  }
+}
@@ -0,0 +1,20 @@
+# Switch a single ECS service to the high level for two hours.
+# Invoke with: terraform apply -invoke=action.sss_set_level.api_high
+action "sss_set_level" "api_high" {
+  config {
+    scalable_type = "ecs"
+    scalable_id   = sss_ecs_scaling.api.service_id
+    region        = "eu-west-1"
+    level         = "high"
+    duration      = "2h"
+  }
+}
+
+# Switch every scalable in a group to the extreme level until the override is
+# cleared with sss_clear_override.
+action "sss_set_level" "live_sports_extreme" {
+  config {
+    group = "live-sports"
+    level = "extreme"
+  }
+}
@@ -0,0 +1,14 @@
+# Issue a read-only token for a Lambda function without storing the
+# provider password in state. Requires Terraform 1.11+ for write-only
+# attributes.
+ephemeral "sss_api_token" "reader" {
+  scopes = ["read"]
+  ttl    = "15m"
+}
+
+resource "aws_ssm_parameter" "sss_token" {
+  name             = "/scaling/sss-token"
+  type             = "SecureString"
+  value_wo         = ephemeral.sss_api_token.reader.token
+  value_wo_version = 1
+}
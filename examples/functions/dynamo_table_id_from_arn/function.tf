@@ -0,0 +1,10 @@
+resource "sss_dynamo_table_scaling" "entries" {
+  table_name = provider::sss::dynamo_table_id_from_arn(aws_dynamodb_table.entries.arn)
+  region     = "eu-west-1"
+  capacity = {
+    low     = { min_write = 1, max_write = 5, min_read = 1, max_read = 5 }
+    medium  = { min_write = 2, max_write = 10, min_read = 2, max_read = 10 }
+    high    = { min_write = 5, max_write = 25, min_read = 5, max_read = 25 }
+    extreme = { min_write = 10, max_write = 50, min_read = 10, max_read = 50 }
+  }
+}
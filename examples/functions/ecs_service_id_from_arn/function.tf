@@ -0,0 +1,10 @@
+resource "sss_ecs_scaling" "app" {
+  service_id = provider::sss::ecs_service_id_from_arn(aws_ecs_service.app.id)
+  region     = "eu-west-1"
+  min_tasks = {
+    low     = 3
+    medium  = 4
+    high    = 5
+    extreme = 6
+  }
+}
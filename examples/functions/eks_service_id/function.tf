@@ -0,0 +1,14 @@
+resource "sss_eks_hpa_scaling" "alloy_metrics" {
+  service_id = provider::sss::eks_service_id("alloy", "alloy-metrics", "coreeks-main")
+  cluster    = "coreeks-main"
+  region     = "eu-west-1"
+  namespace  = "alloy"
+  name       = "alloy-metrics"
+  kind       = "HPA"
+  min_replicas = {
+    low     = 4
+    medium  = 6
+    high    = 10
+    extreme = 15
+  }
+}
@@ -0,0 +1,14 @@
+locals {
+  entries_table = provider::sss::parse_dynamo_table_arn(aws_dynamodb_table.entries.arn)
+}
+
+resource "sss_dynamo_table_scaling" "entries" {
+  table_name = local.entries_table.id
+  region     = local.entries_table.region
+  capacity = {
+    low     = { min_write = 1, max_write = 5, min_read = 1, max_read = 5 }
+    medium  = { min_write = 2, max_write = 10, min_read = 2, max_read = 10 }
+    high    = { min_write = 5, max_write = 25, min_read = 5, max_read = 25 }
+    extreme = { min_write = 10, max_write = 50, min_read = 10, max_read = 50 }
+  }
+}
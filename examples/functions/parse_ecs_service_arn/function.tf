@@ -0,0 +1,14 @@
+locals {
+  app_service = provider::sss::parse_ecs_service_arn(aws_ecs_service.app.id)
+}
+
+resource "sss_ecs_scaling" "app" {
+  service_id = local.app_service.id
+  region     = local.app_service.region
+  min_tasks = {
+    low     = 3
+    medium  = 4
+    high    = 5
+    extreme = 6
+  }
+}
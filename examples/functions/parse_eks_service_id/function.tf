@@ -0,0 +1,7 @@
+locals {
+  alloy_metrics = provider::sss::parse_eks_service_id("alloy/alloy-metrics@coreeks-main")
+}
+
+output "alloy_metrics_cluster" {
+  value = local.alloy_metrics.cluster
+}
@@ -0,0 +1,14 @@
+resource "sss_ecs_scaling" "app" {
+  service_id = "service/coreecs-general-cluster-fargate-main-ew1/corecwbatcher-general-app"
+  region     = "eu-west-1"
+  min_tasks  = provider::sss::scale_levels(3, "standard", null)
+}
+
+resource "sss_eks_hpa_scaling" "alloy_metrics" {
+  cluster      = "coreeks-main"
+  region       = "eu-west-1"
+  namespace    = "alloy"
+  name         = "alloy-metrics"
+  kind         = "HPA"
+  min_replicas = provider::sss::scale_levels(4, { low = 1, medium = 1.5, high = 2.5, extreme = 4 }, { max = 15 })
+}
@@ -0,0 +1,3 @@
+list "sss_dynamo_table_scaling" "all" {
+  provider = sss
+}
@@ -0,0 +1,17 @@
+list "sss_ecs_scaling" "eu_west_1" {
+  provider = sss
+
+  config {
+    region = "eu-west-1"
+  }
+}
+
+list "sss_ecs_scaling" "core_team" {
+  provider = sss
+
+  config {
+    tags = {
+      team = "core"
+    }
+  }
+}
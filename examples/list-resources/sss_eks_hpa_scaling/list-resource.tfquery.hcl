@@ -0,0 +1,4 @@
+list "sss_eks_hpa_scaling" "all" {
+  provider         = sss
+  include_resource = true
+}
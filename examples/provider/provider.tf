@@ -0,0 +1,47 @@
+# Credentials from the "ci" profile of ~/.sss/credentials:
+#
+#   [ci]
+#   auth_username = terraform
+#   auth_password = ...
+provider "sss" {
+  host    = "sss.example.com"
+  profile = "ci"
+
+  # Tags registered with every scalable, like the AWS provider default_tags.
+  default_tags {
+    tags = {
+      team       = "core"
+      managed_by = "terraform"
+    }
+  }
+
+  # Fail plans with capacities beyond what any service should need.
+  guardrails {
+    max_ecs_tasks             = 100
+    max_dynamo_write_capacity = 40000
+    max_extreme_low_ratio     = 10
+  }
+}
+
+# Credentials from an ephemeral resource, so the password never ends up in a
+# plan or state file.
+ephemeral "vault_kv_secret_v2" "sss" {
+  mount = "secret"
+  name  = "scheduled-scaling-service"
+}
+
+provider "sss" {
+  alias         = "vault"
+  host          = "sss.example.com"
+  auth_username = ephemeral.vault_kv_secret_v2.sss.data.username
+  auth_password = ephemeral.vault_kv_secret_v2.sss.data.password
+}
+
+# An SSS behind an internal CA, reached through the corporate egress proxy.
+provider "sss" {
+  alias        = "internal"
+  host         = "sss.internal.example.com"
+  profile      = "ci"
+  ca_cert_file = "/etc/ssl/certs/internal-ca.pem"
+  proxy_url    = "http://proxy.example.com:3128"
+}
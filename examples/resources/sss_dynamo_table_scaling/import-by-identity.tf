@@ -0,0 +1,7 @@
+import {
+  to = sss_dynamo_table_scaling.example
+  identity = {
+    scalable_id = "table/a2dcmsapi-mtvsync-entrytable"
+    region      = "eu-west-1"
+  }
+}
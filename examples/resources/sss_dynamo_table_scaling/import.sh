@@ -0,0 +1,4 @@
+# Scaling can be imported by specifying the table ID, optionally prefixed with
+# the region and followed by an endpoint qualifier.
+tofu import sss_dynamo_table_scaling.example table/table_name
+tofu import sss_dynamo_table_scaling.example 'eu-north-1:table/table_name?endpoint=sss-en1.example.com'
@@ -0,0 +1,7 @@
+import {
+  to = sss_ecs_scaling.example
+  identity = {
+    scalable_id = "service/coreecs-general-cluster-fargate-main-ew1/corecwbatcher-general-app"
+    region      = "eu-west-1"
+  }
+}
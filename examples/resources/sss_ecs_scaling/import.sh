@@ -1,2 +1,9 @@
-# Scaling can be imported by specifying the service identifier.
-tofu import sss_ecs_scaling.example cluster_name/service_name
+# Scaling can be imported by specifying the service identifier, optionally
+# prefixed with the region so the first read goes to the SSS endpoint of that
+# region.
+tofu import sss_ecs_scaling.example service/cluster_name/service_name
+tofu import sss_ecs_scaling.example eu-north-1:service/cluster_name/service_name
+
+# Scalables registered with an SSS host other than the provider endpoint of
+# their region can be imported with an endpoint qualifier.
+tofu import sss_ecs_scaling.example 'eu-north-1:service/cluster_name/service_name?endpoint=sss-en1.example.com'
@@ -7,4 +7,47 @@ resource "sss_ecs_scaling" "test" {
     high    = 5
     extreme = 6
   }
+
+  owner   = "core"
+  contact = "#core-oncall"
+  tags = {
+    service = "corecwbatcher"
+  }
+}
+
+resource "sss_ecs_scaling" "slow_sss" {
+  service_id = "service/coreecs-general-cluster-fargate-main-ew1/corecwbatcher-general-worker"
+  region     = "eu-west-1"
+  min_tasks = {
+    low     = 1
+    medium  = 2
+    high    = 3
+    extreme = 4
+  }
+
+  # Wait until SSS has scaled the service before smoke tests run.
+  wait_for_apply = {
+    poll_interval = "30s"
+  }
+
+  timeouts {
+    create = "10m"
+    update = "10m"
+  }
+}
+
+resource "sss_ecs_scaling" "incident" {
+  service_id = "service/coreecs-general-cluster-fargate-main-ew1/corecwbatcher-general-api"
+  region     = "eu-west-1"
+  min_tasks = {
+    low     = 2
+    medium  = 2
+    high    = 4
+    extreme = 8
+  }
+
+  # Keep SSS away from the service during incident response. SSS resumes
+  # scaling it on its own once suspended_until has passed.
+  suspended       = true
+  suspended_until = "2027-11-01T18:00:00Z"
 }
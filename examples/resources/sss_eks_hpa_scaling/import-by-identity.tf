@@ -0,0 +1,7 @@
+import {
+  to = sss_eks_hpa_scaling.example
+  identity = {
+    scalable_id = "alloy/alloy-metrics@coreeks-main"
+    region      = "eu-west-1"
+  }
+}
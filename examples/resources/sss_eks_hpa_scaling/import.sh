@@ -1,2 +1,4 @@
-# Scaling can be imported by specifying the SSS service ID.
+# Scaling can be imported by specifying the SSS service ID, optionally
+# prefixed with the region and followed by an endpoint qualifier.
 tofu import sss_eks_hpa_scaling.example 'namespace/name@cluster'
+tofu import sss_eks_hpa_scaling.example 'eu-west-1:namespace/name@cluster?endpoint=sss-ew1.example.com'
@@ -13,13 +13,13 @@ resource "sss_eks_hpa_scaling" "alloy_metrics" {
   }
 }
 
+# service_id defaults to "tempo/tempo-distributor@coreeks-main" when omitted.
 resource "sss_eks_hpa_scaling" "tempo_distributor" {
-  service_id = "tempo/tempo-distributor@coreeks-main"
-  cluster    = "coreeks-main"
-  region     = "eu-west-1"
-  namespace  = "tempo"
-  name       = "tempo-distributor"
-  kind       = "ScaledObject"
+  cluster   = "coreeks-main"
+  region    = "eu-west-1"
+  namespace = "tempo"
+  name      = "tempo-distributor"
+  kind      = "ScaledObject"
   min_replicas = {
     low     = 2
     medium  = 3
@@ -0,0 +1,166 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+// Package cli implements the companion subcommands of the provider binary,
+// which talk to the Scheduled Scaling Service directly instead of through
+// Terraform.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-sss/internal/client"
+)
+
+// Command runs a subcommand with the arguments following its name and returns
+// the process exit code.
+type Command func(args []string, stdout io.Writer, stderr io.Writer) int
+
+// Commands maps subcommand names to their implementation.
+var Commands = map[string]Command{
+	"export": Export,
+	"drift":  Drift,
+}
+
+// clientFlags holds the flags used to connect to SSS. Each flag defaults to
+// the environment variable of the same name, so secrets do not have to be
+// passed on the command line.
+type clientFlags struct {
+	host      string
+	protocol  string
+	username  string
+	password  string
+	endpoints string
+	// credentialsFile and profile select a credentials file profile to
+	// read the username and password from when they are not set.
+	credentialsFile string
+	profile         string
+	// The TLS and proxy flags match the provider attributes of the same
+	// name, with files instead of PEM encoded values.
+	caCertFile         string
+	clientCertFile     string
+	clientKeyFile      string
+	insecureSkipVerify bool
+	proxyURL           string
+}
+
+func (f *clientFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.host, "host", os.Getenv("SSS_HOST"), "the Scheduled Scaling Service API endpoint to connect to (env SSS_HOST)")
+	fs.StringVar(&f.protocol, "protocol", envOrDefault("SSS_PROTOCOL", "https"), "the protocol to use when connecting to SSS (env SSS_PROTOCOL)")
+	fs.StringVar(&f.username, "auth-username", os.Getenv("SSS_AUTH_USERNAME"), "the basicauth username to authenticate with (env SSS_AUTH_USERNAME)")
+	fs.StringVar(&f.password, "auth-password", os.Getenv("SSS_AUTH_PASSWORD"), "the basicauth password to authenticate with (env SSS_AUTH_PASSWORD)")
+	fs.StringVar(&f.credentialsFile, "credentials-file", os.Getenv("SSS_CREDENTIALS_FILE"), "the credentials file to read -auth-username and -auth-password from when not set, defaults to ~/.sss/credentials (env SSS_CREDENTIALS_FILE)")
+	fs.StringVar(&f.profile, "profile", os.Getenv("SSS_PROFILE"), "the credentials file profile to use, defaults to default (env SSS_PROFILE)")
+	fs.StringVar(&f.endpoints, "endpoints", os.Getenv("SSS_ENDPOINTS"), "comma separated regional endpoints, e.g. eu-north-1=sss-en1.example.com (env SSS_ENDPOINTS)")
+	fs.StringVar(&f.caCertFile, "ca-cert-file", os.Getenv("SSS_CA_CERT_FILE"), "a file with PEM encoded certificates of CAs to trust in addition to the system CAs (env SSS_CA_CERT_FILE)")
+	fs.StringVar(&f.clientCertFile, "client-cert-file", os.Getenv("SSS_CLIENT_CERT_FILE"), "a file with the PEM encoded client certificate to authenticate with mutual TLS, requires -client-key-file (env SSS_CLIENT_CERT_FILE)")
+	fs.StringVar(&f.clientKeyFile, "client-key-file", os.Getenv("SSS_CLIENT_KEY_FILE"), "a file with the PEM encoded private key of -client-cert-file (env SSS_CLIENT_KEY_FILE)")
+	insecureSkipVerify, _ := strconv.ParseBool(os.Getenv("SSS_INSECURE_SKIP_VERIFY"))
+	fs.BoolVar(&f.insecureSkipVerify, "insecure-skip-verify", insecureSkipVerify, "skip verifying the TLS certificate of SSS, only meant for testing (env SSS_INSECURE_SKIP_VERIFY)")
+	fs.StringVar(&f.proxyURL, "proxy-url", os.Getenv("SSS_PROXY_URL"), "the URL of an http, https or socks5 proxy to connect to SSS through, defaults to HTTPS_PROXY, HTTP_PROXY and NO_PROXY (env SSS_PROXY_URL)")
+}
+
+// client builds an SssClient from the flags, and writes warnings about them
+// to stderr.
+func (f *clientFlags) client(stderr io.Writer) (*client.SssClient, error) {
+	if f.host == "" {
+		return nil, fmt.Errorf("-host or SSS_HOST is required")
+	}
+	if err := client.ValidateHost(f.host); err != nil {
+		return nil, fmt.Errorf("invalid -host: %w", err)
+	}
+	if f.protocol != "http" && f.protocol != "https" {
+		return nil, fmt.Errorf("-protocol must be http or https, got %q", f.protocol)
+	}
+	credentials := client.Credentials{AuthUsername: f.username, AuthPassword: f.password}
+	if err := client.FillCredentials(&credentials, f.credentialsFile, f.profile); err != nil {
+		return nil, err
+	}
+	if credentials.AuthUsername == "" || credentials.AuthPassword == "" {
+		return nil, fmt.Errorf("-auth-username and -auth-password, SSS_AUTH_USERNAME and SSS_AUTH_PASSWORD, or a -credentials-file profile are required")
+	}
+
+	endpoints := map[string]string{}
+	for _, endpoint := range strings.Split(f.endpoints, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
+		}
+		region, host, found := strings.Cut(endpoint, "=")
+		if !found || region == "" || host == "" {
+			return nil, fmt.Errorf("invalid endpoint %q, expected REGION=HOST", endpoint)
+		}
+		if err := client.ValidateHost(host); err != nil {
+			return nil, fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+		}
+		endpoints[region] = host
+	}
+
+	options, err := f.transportOptions(stderr)
+	if err != nil {
+		return nil, err
+	}
+	options = append(options, client.WithEndpoints(endpoints))
+	return client.NewSssClient(f.host, f.protocol, credentials.AuthUsername, credentials.AuthPassword, options...), nil
+}
+
+// transportOptions returns the client options for the TLS and proxy flags.
+func (f *clientFlags) transportOptions(stderr io.Writer) ([]client.SssClientOption, error) {
+	var options []client.SssClientOption
+	settings := client.TLSSettings{InsecureSkipVerify: f.insecureSkipVerify}
+	var err error
+	if f.caCertFile != "" {
+		if settings.CACertPEM, err = client.ReadCACertFile(f.caCertFile); err != nil {
+			return nil, err
+		}
+	}
+	if f.clientCertFile != "" {
+		if settings.ClientCertPEM, err = readPEMFile("-client-cert-file", f.clientCertFile); err != nil {
+			return nil, err
+		}
+	}
+	if f.clientKeyFile != "" {
+		if settings.ClientKeyPEM, err = readPEMFile("-client-key-file", f.clientKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	tlsConfig, err := settings.Config()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		options = append(options, client.WithTLSConfig(tlsConfig))
+	}
+	if f.insecureSkipVerify {
+		_, _ = fmt.Fprintln(stderr, "Warning: -insecure-skip-verify is set, so the certificate of SSS is not verified and the credentials sent to it can be intercepted.")
+	}
+
+	if f.proxyURL != "" {
+		proxyURL, err := client.ParseProxyURL(f.proxyURL)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, client.WithProxy(proxyURL))
+	}
+	return options, nil
+}
+
+// readPEMFile reads the PEM encoded file given by the flag name.
+func readPEMFile(name string, filename string) (string, error) {
+	pem, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return string(pem), nil
+}
+
+func envOrDefault(key string, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
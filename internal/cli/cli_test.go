@@ -0,0 +1,65 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClientFlagsTransport(t *testing.T) {
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantErr     string
+		wantWarning string
+	}{
+		{name: "defaults"},
+		{name: "proxy", args: []string{"-proxy-url", "socks5://proxy.example.com:1080"}},
+		{name: "insecure", args: []string{"-insecure-skip-verify"}, wantWarning: "-insecure-skip-verify is set"},
+		{name: "invalid proxy", args: []string{"-proxy-url", "ftp://proxy.example.com"}, wantErr: "the scheme must be http, https or socks5"},
+		{name: "missing CA file", args: []string{"-ca-cert-file", filepath.Join(t.TempDir(), "missing.pem")}, wantErr: "failed to read CA certificate file"},
+		{name: "invalid CA file", args: []string{"-ca-cert-file", caCertFile}, wantErr: "holds no PEM encoded certificates"},
+		{name: "client cert without key", args: []string{"-client-cert-file", caCertFile}, wantErr: "must be given together"},
+		{name: "missing client key file", args: []string{"-client-cert-file", caCertFile, "-client-key-file", filepath.Join(t.TempDir(), "missing.pem")}, wantErr: "failed to read -client-key-file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, name := range []string{"SSS_CA_CERT_FILE", "SSS_CLIENT_CERT_FILE", "SSS_CLIENT_KEY_FILE", "SSS_INSECURE_SKIP_VERIFY", "SSS_PROXY_URL"} {
+				t.Setenv(name, "")
+			}
+			var flags clientFlags
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.register(fs)
+			args := append([]string{"-host", "sss.example.com", "-auth-username", "user", "-auth-password", "secret"}, tt.args...)
+			if err := fs.Parse(args); err != nil {
+				t.Fatal(err)
+			}
+
+			var stderr bytes.Buffer
+			_, err := flags.client(&stderr)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("client() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("client() returned error: %v", err)
+			}
+			if !strings.Contains(stderr.String(), tt.wantWarning) || (tt.wantWarning == "" && stderr.Len() > 0) {
+				t.Fatalf("stderr = %q, want %q", stderr.String(), tt.wantWarning)
+			}
+		})
+	}
+}
@@ -0,0 +1,445 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-sss/internal/client"
+)
+
+// Exit code of the drift command when at least one resource has drifted.
+const driftDetectedExitCode = 3
+
+const (
+	driftStatusInSync    = "in_sync"
+	driftStatusDrifted   = "drifted"
+	driftStatusMissing   = "missing"
+	driftStatusUnmanaged = "unmanaged"
+	driftStatusError     = "error"
+)
+
+// driftFinding is the result of comparing one sss_* resource to SSS.
+type driftFinding struct {
+	StateFile    string            `json:"state_file"`
+	Address      string            `json:"address,omitempty"`
+	ResourceType string            `json:"resource_type"`
+	ScalableID   string            `json:"scalable_id"`
+	Region       string            `json:"region"`
+	Status       string            `json:"status"`
+	Differences  []driftDifference `json:"differences,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// driftDifference is an attribute whose value in state differs from SSS.
+type driftDifference struct {
+	Attribute string `json:"attribute"`
+	State     string `json:"state"`
+	SSS       string `json:"sss"`
+}
+
+// driftResourceType describes how to compare one resource type to SSS.
+type driftResourceType struct {
+	idAttribute string
+	// fetch returns the registration in SSS flattened to resource attribute
+	// paths, e.g. "min_tasks.low".
+	fetch func(ctx context.Context, c *client.SssClient, id string) (map[string]string, error)
+	// list returns the IDs and regions of every registration of the type.
+	list func(ctx context.Context, c *client.SssClient) ([][2]string, error)
+}
+
+var driftResourceTypes = map[string]driftResourceType{
+	"sss_ecs_scaling": {
+		idAttribute: "service_id",
+		fetch: func(ctx context.Context, c *client.SssClient, id string) (map[string]string, error) {
+			service, err := c.GetEcsService(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return withOwnership(map[string]string{
+				"min_tasks.low":     strconv.FormatInt(service.MinLowCapacity, 10),
+				"min_tasks.medium":  strconv.FormatInt(service.MinMediumCapacity, 10),
+				"min_tasks.high":    strconv.FormatInt(service.MinHighCapacity, 10),
+				"min_tasks.extreme": strconv.FormatInt(service.MinExtremeCapacity, 10),
+				"suspended":         strconv.FormatBool(service.Suspended),
+			}, service.Tags, service.Owner, service.Contact), nil
+		},
+		list: func(ctx context.Context, c *client.SssClient) ([][2]string, error) {
+			services, err := c.ListEcsServices(ctx)
+			ids := make([][2]string, 0, len(services))
+			for _, service := range services {
+				ids = append(ids, [2]string{service.Name, service.Region})
+			}
+			return ids, err
+		},
+	},
+	"sss_dynamo_table_scaling": {
+		idAttribute: "table_name",
+		fetch: func(ctx context.Context, c *client.SssClient, id string) (map[string]string, error) {
+			table, err := c.GetDynamoTable(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			attributes := map[string]string{
+				"suspended": strconv.FormatBool(table.Suspended),
+			}
+			for level, capacity := range map[string]client.DynamoTableCapacity{
+				"low":     table.LowCapacity,
+				"medium":  table.MediumCapacity,
+				"high":    table.HighCapacity,
+				"extreme": table.ExtremeCapacity,
+			} {
+				attributes["capacity."+level+".min_write"] = strconv.FormatInt(capacity.MinWriteCapacity, 10)
+				attributes["capacity."+level+".max_write"] = strconv.FormatInt(capacity.MaxWriteCapacity, 10)
+				attributes["capacity."+level+".min_read"] = strconv.FormatInt(capacity.MinReadCapacity, 10)
+				attributes["capacity."+level+".max_read"] = strconv.FormatInt(capacity.MaxReadCapacity, 10)
+			}
+			return withOwnership(attributes, table.Tags, table.Owner, table.Contact), nil
+		},
+		list: func(ctx context.Context, c *client.SssClient) ([][2]string, error) {
+			tables, err := c.ListDynamoTables(ctx)
+			ids := make([][2]string, 0, len(tables))
+			for _, table := range tables {
+				ids = append(ids, [2]string{table.TableName, table.Region})
+			}
+			return ids, err
+		},
+	},
+	"sss_eks_hpa_scaling": {
+		idAttribute: "service_id",
+		fetch: func(ctx context.Context, c *client.SssClient, id string) (map[string]string, error) {
+			hpa, err := c.GetEksHpa(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			return withOwnership(map[string]string{
+				"cluster":              hpa.Cluster,
+				"namespace":            hpa.Namespace,
+				"name":                 hpa.Name,
+				"kind":                 hpa.Kind,
+				"min_replicas.low":     strconv.FormatInt(hpa.MinLow, 10),
+				"min_replicas.medium":  strconv.FormatInt(hpa.MinMedium, 10),
+				"min_replicas.high":    strconv.FormatInt(hpa.MinHigh, 10),
+				"min_replicas.extreme": strconv.FormatInt(hpa.MinExtreme, 10),
+				"suspended":            strconv.FormatBool(hpa.Suspended),
+			}, hpa.Tags, hpa.Owner, hpa.Contact), nil
+		},
+		list: func(ctx context.Context, c *client.SssClient) ([][2]string, error) {
+			hpas, err := c.ListEksHpas(ctx)
+			ids := make([][2]string, 0, len(hpas))
+			for _, hpa := range hpas {
+				ids = append(ids, [2]string{hpa.ID, hpa.Region})
+			}
+			return ids, err
+		},
+	},
+}
+
+// withOwnership adds the tags, owner and contact of a registration to its
+// flattened attributes. Tags are compared with tags_all, which includes the
+// provider default tags.
+func withOwnership(attributes map[string]string, tags map[string]string, owner string, contact string) map[string]string {
+	for key, value := range tags {
+		attributes["tags_all."+key] = value
+	}
+	attributes["owner"] = owner
+	attributes["contact"] = contact
+	return attributes
+}
+
+// stateModule is the subset of a module in "terraform show -json" output
+// needed to find sss_* resources.
+type stateModule struct {
+	Resources []struct {
+		Address string         `json:"address"`
+		Mode    string         `json:"mode"`
+		Type    string         `json:"type"`
+		Values  map[string]any `json:"values"`
+	} `json:"resources"`
+	ChildModules []stateModule `json:"child_modules"`
+}
+
+type stateFile struct {
+	Values *struct {
+		RootModule stateModule `json:"root_module"`
+	} `json:"values"`
+}
+
+// Drift compares the sss_* resources in one or more Terraform state files,
+// as written by "terraform show -json", with the registrations in SSS.
+func Drift(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("drift", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: terraform-provider-sss drift [flags] STATE_FILE...")
+		_, _ = fmt.Fprintln(stderr, "")
+		_, _ = fmt.Fprintln(stderr, "Compares the sss_* resources in state files written by \"terraform show -json\"")
+		_, _ = fmt.Fprintln(stderr, "with SSS. Use \"-\" to read a state file from stdin.")
+		_, _ = fmt.Fprintln(stderr, "")
+		_, _ = fmt.Fprintf(stderr, "Exits with 0 when everything is in sync, %d when drift is detected and 1 on errors.\n", driftDetectedExitCode)
+		_, _ = fmt.Fprintln(stderr, "")
+		fs.PrintDefaults()
+	}
+
+	var flags clientFlags
+	flags.register(fs)
+	format := fs.String("format", "table", "the output format: table, json or junit")
+	includeUnmanaged := fs.Bool("include-unmanaged", false, "also report SSS registrations that are not in any of the state files")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	writeReport, ok := driftReportFormats[*format]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "Error: unknown format %q, expected table, json or junit\n", *format)
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	sssClient, err := flags.client(stderr)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error:", err)
+		return 2
+	}
+
+	ctx := context.Background()
+	var findings []driftFinding
+	for _, filename := range fs.Args() {
+		stateFindings, err := driftFromStateFile(ctx, sssClient, filename)
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, "Error:", err)
+			return 1
+		}
+		findings = append(findings, stateFindings...)
+	}
+
+	if *includeUnmanaged {
+		unmanaged, err := unmanagedFindings(ctx, sssClient, findings)
+		if err != nil {
+			_, _ = fmt.Fprintln(stderr, "Error:", err)
+			return 1
+		}
+		findings = append(findings, unmanaged...)
+	}
+
+	if err := writeReport(stdout, findings); err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+
+	exitCode := 0
+	for _, finding := range findings {
+		switch finding.Status {
+		case driftStatusError:
+			return 1
+		case driftStatusDrifted, driftStatusMissing, driftStatusUnmanaged:
+			exitCode = driftDetectedExitCode
+		}
+	}
+	return exitCode
+}
+
+// driftFromStateFile compares every sss_* resource in a state file with SSS.
+func driftFromStateFile(ctx context.Context, sssClient *client.SssClient, filename string) ([]driftFinding, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state stateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("%s is not valid \"terraform show -json\" output: %w", filename, err)
+	}
+	if state.Values == nil {
+		return nil, nil
+	}
+
+	var findings []driftFinding
+	modules := []stateModule{state.Values.RootModule}
+	for len(modules) > 0 {
+		module := modules[0]
+		modules = append(modules[1:], module.ChildModules...)
+
+		for _, resource := range module.Resources {
+			resourceType, ok := driftResourceTypes[resource.Type]
+			if resource.Mode != "managed" || !ok {
+				continue
+			}
+
+			id, _ := resource.Values[resourceType.idAttribute].(string)
+			region, _ := resource.Values["region"].(string)
+			finding := driftFinding{
+				StateFile:    filename,
+				Address:      resource.Address,
+				ResourceType: resource.Type,
+				ScalableID:   id,
+				Region:       region,
+				Status:       driftStatusInSync,
+			}
+
+			registered, err := resourceType.fetch(ctx, scalableClient(sssClient, resource.Values), id)
+			switch {
+			case errors.Is(err, client.ErrNotFound):
+				finding.Status = driftStatusMissing
+			case err != nil:
+				finding.Status = driftStatusError
+				finding.Error = err.Error()
+			default:
+				finding.Differences = driftDifferences(flattenStateValues("", resource.Values), registered)
+				if len(finding.Differences) > 0 {
+					finding.Status = driftStatusDrifted
+				}
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings, nil
+}
+
+// scalableClient returns the client for the SSS endpoint of a resource in
+// state: the endpoint it was created at when set, otherwise the endpoint of
+// its region.
+func scalableClient(sssClient *client.SssClient, values map[string]any) *client.SssClient {
+	if endpoint, _ := values["endpoint"].(string); endpoint != "" {
+		return sssClient.ForHost(endpoint)
+	}
+	region, _ := values["region"].(string)
+	return sssClient.ForRegion(region)
+}
+
+// unmanagedFindings reports the SSS registrations of the resource types that
+// were not found in any state file.
+func unmanagedFindings(ctx context.Context, sssClient *client.SssClient, managed []driftFinding) ([]driftFinding, error) {
+	seen := map[string]bool{}
+	for _, finding := range managed {
+		seen[finding.ResourceType+"/"+finding.Region+"/"+finding.ScalableID] = true
+	}
+
+	resourceTypes := make([]string, 0, len(driftResourceTypes))
+	for resourceType := range driftResourceTypes {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	var findings []driftFinding
+	for _, endpoint := range sssClient.ForAllEndpoints() {
+		for _, resourceType := range resourceTypes {
+			ids, err := driftResourceTypes[resourceType].list(ctx, endpoint)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range ids {
+				key := resourceType + "/" + id[1] + "/" + id[0]
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				findings = append(findings, driftFinding{
+					ResourceType: resourceType,
+					ScalableID:   id[0],
+					Region:       id[1],
+					Status:       driftStatusUnmanaged,
+				})
+			}
+		}
+	}
+	return findings, nil
+}
+
+// flattenStateValues flattens nested state values to attribute paths.
+func flattenStateValues(prefix string, values map[string]any) map[string]string {
+	flattened := map[string]string{}
+	for name, value := range values {
+		switch v := value.(type) {
+		case map[string]any:
+			for nestedName, nestedValue := range flattenStateValues(prefix+name+".", v) {
+				flattened[nestedName] = nestedValue
+			}
+		case float64:
+			flattened[prefix+name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			flattened[prefix+name] = v
+		case nil:
+			flattened[prefix+name] = ""
+		default:
+			flattened[prefix+name] = fmt.Sprint(v)
+		}
+	}
+	return flattened
+}
+
+// driftMapAttributes are the map attributes compared with SSS. Their keys
+// vary, so a key may be missing from either state or SSS.
+var driftMapAttributes = []string{"tags_all"}
+
+// driftDifferences returns the attributes whose value differs between state
+// and SSS, sorted by attribute. Attributes registered in SSS are compared
+// with state, and the elements of map attributes in state are compared with
+// SSS, so a tag removed in SSS shows as drift too.
+func driftDifferences(state map[string]string, registered map[string]string) []driftDifference {
+	var differences []driftDifference
+	for attribute, value := range registered {
+		if state[attribute] != value {
+			differences = append(differences, driftDifference{Attribute: attribute, State: state[attribute], SSS: value})
+		}
+	}
+	for attribute, value := range state {
+		if _, ok := registered[attribute]; ok || !isDriftMapElement(attribute) {
+			continue
+		}
+		differences = append(differences, driftDifference{Attribute: attribute, State: value})
+	}
+	sort.Slice(differences, func(i, j int) bool {
+		return differences[i].Attribute < differences[j].Attribute
+	})
+	return differences
+}
+
+// isDriftMapElement reports whether a flattened attribute path is an element
+// of one of driftMapAttributes.
+func isDriftMapElement(attribute string) bool {
+	for _, name := range driftMapAttributes {
+		if strings.HasPrefix(attribute, name+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// summary describes the finding in a single line.
+func (f driftFinding) summary() string {
+	switch f.Status {
+	case driftStatusMissing:
+		return "not registered in SSS"
+	case driftStatusUnmanaged:
+		return "registered in SSS but not in state"
+	case driftStatusError:
+		return f.Error
+	}
+	details := make([]string, 0, len(f.Differences))
+	for _, difference := range f.Differences {
+		details = append(details, fmt.Sprintf("%s: state=%s sss=%s", difference.Attribute, difference.State, difference.SSS))
+	}
+	return strings.Join(details, "; ")
+}
@@ -0,0 +1,124 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// driftReportFormats maps the -format flag values to report writers.
+var driftReportFormats = map[string]func(io.Writer, []driftFinding) error{
+	"table": writeDriftTable,
+	"json":  writeDriftJSON,
+	"junit": writeDriftJUnit,
+}
+
+func writeDriftTable(w io.Writer, findings []driftFinding) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if _, err := fmt.Fprintln(tw, "ADDRESS\tSCALABLE\tREGION\tSTATUS\tDETAILS"); err != nil {
+		return err
+	}
+	for _, finding := range findings {
+		address := finding.Address
+		if address == "" {
+			address = "-"
+		}
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", address, finding.ScalableID, finding.Region, finding.Status, finding.summary()); err != nil {
+			return err
+		}
+	}
+	return tw.Flush()
+}
+
+func writeDriftJSON(w io.Writer, findings []driftFinding) error {
+	if findings == nil {
+		findings = []driftFinding{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(findings)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+}
+
+// writeDriftJUnit writes one test suite per state file, and one for
+// unmanaged registrations, with a test case per resource.
+func writeDriftJUnit(w io.Writer, findings []driftFinding) error {
+	report := junitTestSuites{Name: "sss-drift"}
+	suites := map[string]int{}
+	for _, finding := range findings {
+		suiteName := finding.StateFile
+		if suiteName == "" {
+			suiteName = "unmanaged"
+		}
+		index, ok := suites[suiteName]
+		if !ok {
+			index = len(report.Suites)
+			suites[suiteName] = index
+			report.Suites = append(report.Suites, junitTestSuite{Name: suiteName})
+		}
+		suite := &report.Suites[index]
+
+		name := finding.Address
+		if name == "" {
+			name = finding.ResourceType + "[" + finding.Region + "/" + finding.ScalableID + "]"
+		}
+		testCase := junitTestCase{Name: name, ClassName: finding.ResourceType}
+		switch finding.Status {
+		case driftStatusError:
+			testCase.Error = &junitMessage{Message: finding.summary(), Type: finding.Status}
+			suite.Errors++
+			report.Errors++
+		case driftStatusDrifted, driftStatusMissing, driftStatusUnmanaged:
+			testCase.Failure = &junitMessage{Message: finding.summary(), Type: finding.Status}
+			suite.Failures++
+			report.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		report.Tests++
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
@@ -0,0 +1,55 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDriftDifferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      map[string]any
+		registered map[string]string
+		want       []driftDifference
+	}{
+		{
+			name:       "in sync",
+			state:      map[string]any{"service_id": "service/cluster/app", "min_tasks": map[string]any{"low": 1.0}, "tags_all": map[string]any{"team": "video"}},
+			registered: map[string]string{"min_tasks.low": "1", "tags_all.team": "video"},
+		},
+		{
+			name:       "changed in SSS",
+			state:      map[string]any{"min_tasks": map[string]any{"low": 1.0, "high": 4.0}},
+			registered: map[string]string{"min_tasks.low": "1", "min_tasks.high": "8"},
+			want:       []driftDifference{{Attribute: "min_tasks.high", State: "4", SSS: "8"}},
+		},
+		{
+			name:       "tag added in SSS",
+			state:      map[string]any{"tags_all": map[string]any{"team": "video"}},
+			registered: map[string]string{"tags_all.team": "video", "tags_all.cost_center": "1234"},
+			want:       []driftDifference{{Attribute: "tags_all.cost_center", SSS: "1234"}},
+		},
+		{
+			name:       "tag removed in SSS",
+			state:      map[string]any{"tags_all": map[string]any{"team": "video", "cost_center": "1234"}},
+			registered: map[string]string{"tags_all.team": "video"},
+			want:       []driftDifference{{Attribute: "tags_all.cost_center", State: "1234"}},
+		},
+		{
+			name:       "attributes only in state",
+			state:      map[string]any{"endpoint": "sss.example.com", "timeouts": map[string]any{"create": "5m"}, "tags": map[string]any{"team": "video"}},
+			registered: map[string]string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := driftDifferences(flattenStateValues("", tt.state), tt.registered)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("driftDifferences() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"terraform-provider-sss/internal/client"
+	"time"
+)
+
+// exportedScalable is a registration found in SSS, ready to be written as a
+// resource and an import block.
+type exportedScalable struct {
+	resourceType string
+	scalableID   string
+	region       string
+	file         string
+	name         string
+	attributes   []hclAttribute
+}
+
+// Export writes every scalable registered in SSS as Terraform configuration
+// with matching import blocks, one file per region and cluster or namespace.
+func Export(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: terraform-provider-sss export [flags]")
+		_, _ = fmt.Fprintln(stderr, "")
+		_, _ = fmt.Fprintln(stderr, "Writes sss_* resources and import blocks for every scalable registered in SSS.")
+		_, _ = fmt.Fprintln(stderr, "The import blocks use resource identity and require Terraform 1.12 or later.")
+		_, _ = fmt.Fprintln(stderr, "")
+		fs.PrintDefaults()
+	}
+
+	var flags clientFlags
+	flags.register(fs)
+	outDir := fs.String("out", ".", "the directory to write the generated .tf files to")
+	force := fs.Bool("force", false, "overwrite existing files")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	sssClient, err := flags.client(stderr)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error:", err)
+		return 2
+	}
+
+	scalables, err := collectScalables(context.Background(), sssClient)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+
+	files, err := writeScalables(scalables, *outDir, *force)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "Error:", err)
+		return 1
+	}
+
+	_, _ = fmt.Fprintf(stdout, "Exported %d scalables to %d files in %s\n", len(scalables), files, *outDir)
+	return 0
+}
+
+// collectScalables lists the registrations of every scalable type from every
+// configured SSS endpoint.
+func collectScalables(ctx context.Context, sssClient *client.SssClient) ([]exportedScalable, error) {
+	var scalables []exportedScalable
+	seen := map[string]bool{}
+	add := func(scalable exportedScalable) {
+		key := scalable.resourceType + "/" + scalable.region + "/" + scalable.scalableID
+		if !seen[key] {
+			seen[key] = true
+			scalables = append(scalables, scalable)
+		}
+	}
+
+	for _, endpoint := range sssClient.ForAllEndpoints() {
+		ecsServices, err := endpoint.ListEcsServices(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range ecsServices {
+			add(exportEcsService(service))
+		}
+
+		dynamoTables, err := endpoint.ListDynamoTables(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, table := range dynamoTables {
+			add(exportDynamoTable(table))
+		}
+
+		eksHpas, err := endpoint.ListEksHpas(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, hpa := range eksHpas {
+			add(exportEksHpa(hpa))
+		}
+	}
+
+	sort.Slice(scalables, func(i, j int) bool {
+		if scalables[i].file != scalables[j].file {
+			return scalables[i].file < scalables[j].file
+		}
+		return scalables[i].scalableID < scalables[j].scalableID
+	})
+
+	// Resource names must be unique per resource type across all files.
+	used := map[string]int{}
+	for i := range scalables {
+		key := scalables[i].resourceType + "." + scalables[i].name
+		used[key]++
+		if used[key] > 1 {
+			scalables[i].name = fmt.Sprintf("%s_%d", scalables[i].name, used[key])
+		}
+	}
+	return scalables, nil
+}
+
+// ownershipAttributes returns the tags, owner and contact of a registration,
+// leaving out those that are not set.
+func ownershipAttributes(tags map[string]string, owner string, contact string) []hclAttribute {
+	var attributes []hclAttribute
+	if len(tags) > 0 {
+		attributes = append(attributes, hclMapAttribute("tags", tags))
+	}
+	if owner != "" {
+		attributes = append(attributes, hclStringAttribute("owner", owner))
+	}
+	if contact != "" {
+		attributes = append(attributes, hclStringAttribute("contact", contact))
+	}
+	return attributes
+}
+
+// suspendAttributes returns the suspend attributes of a registration, which
+// are left out unless it is suspended.
+func suspendAttributes(suspended bool, suspendedUntil *time.Time) []hclAttribute {
+	if !suspended {
+		return nil
+	}
+	attributes := []hclAttribute{hclBoolAttribute("suspended", true)}
+	if suspendedUntil != nil {
+		attributes = append(attributes, hclStringAttribute("suspended_until", suspendedUntil.UTC().Format(time.RFC3339)))
+	}
+	return attributes
+}
+
+func exportEcsService(service client.EcsServiceResponse) exportedScalable {
+	// ECS service IDs have the form service/CLUSTER_NAME/SERVICE_NAME.
+	segments := strings.Split(service.Name, "/")
+	cluster, name := "default", segments[len(segments)-1]
+	if len(segments) >= 3 {
+		cluster = segments[len(segments)-2]
+	}
+
+	return exportedScalable{
+		resourceType: "sss_ecs_scaling",
+		scalableID:   service.Name,
+		region:       service.Region,
+		file:         strings.Join([]string{"ecs", hclName(service.Region), hclName(cluster)}, "_"),
+		name:         hclName(name),
+		attributes: append([]hclAttribute{
+			hclStringAttribute("service_id", service.Name),
+			hclStringAttribute("region", service.Region),
+			hclObjectAttribute("min_tasks",
+				hclIntAttribute("low", service.MinLowCapacity),
+				hclIntAttribute("medium", service.MinMediumCapacity),
+				hclIntAttribute("high", service.MinHighCapacity),
+				hclIntAttribute("extreme", service.MinExtremeCapacity),
+			),
+		}, slices.Concat(
+			suspendAttributes(service.Suspended, service.SuspendedUntil),
+			ownershipAttributes(service.Tags, service.Owner, service.Contact),
+		)...),
+	}
+}
+
+func exportDynamoTable(table client.DynamoTableResponse) exportedScalable {
+	capacity := func(level string, c client.DynamoTableCapacity) hclAttribute {
+		return hclObjectAttribute(level,
+			hclIntAttribute("min_write", c.MinWriteCapacity),
+			hclIntAttribute("max_write", c.MaxWriteCapacity),
+			hclIntAttribute("min_read", c.MinReadCapacity),
+			hclIntAttribute("max_read", c.MaxReadCapacity),
+		)
+	}
+
+	return exportedScalable{
+		resourceType: "sss_dynamo_table_scaling",
+		scalableID:   table.TableName,
+		region:       table.Region,
+		file:         strings.Join([]string{"dynamo_table", hclName(table.Region)}, "_"),
+		name:         hclName(strings.TrimPrefix(table.TableName, "table/")),
+		attributes: append([]hclAttribute{
+			hclStringAttribute("table_name", table.TableName),
+			hclStringAttribute("region", table.Region),
+			hclObjectAttribute("capacity",
+				capacity("low", table.LowCapacity),
+				capacity("medium", table.MediumCapacity),
+				capacity("high", table.HighCapacity),
+				capacity("extreme", table.ExtremeCapacity),
+			),
+		}, slices.Concat(
+			suspendAttributes(table.Suspended, table.SuspendedUntil),
+			ownershipAttributes(table.Tags, table.Owner, table.Contact),
+		)...),
+	}
+}
+
+func exportEksHpa(hpa client.EksHpaResponse) exportedScalable {
+	return exportedScalable{
+		resourceType: "sss_eks_hpa_scaling",
+		scalableID:   hpa.ID,
+		region:       hpa.Region,
+		file:         strings.Join([]string{"eks_hpa", hclName(hpa.Region), hclName(hpa.Cluster), hclName(hpa.Namespace)}, "_"),
+		name:         hclName(hpa.Namespace + "_" + hpa.Name),
+		attributes: append([]hclAttribute{
+			hclStringAttribute("service_id", hpa.ID),
+			hclStringAttribute("cluster", hpa.Cluster),
+			hclStringAttribute("region", hpa.Region),
+			hclStringAttribute("namespace", hpa.Namespace),
+			hclStringAttribute("name", hpa.Name),
+			hclStringAttribute("kind", hpa.Kind),
+			hclObjectAttribute("min_replicas",
+				hclIntAttribute("low", hpa.MinLow),
+				hclIntAttribute("medium", hpa.MinMedium),
+				hclIntAttribute("high", hpa.MinHigh),
+				hclIntAttribute("extreme", hpa.MinExtreme),
+			),
+		}, slices.Concat(
+			suspendAttributes(hpa.Suspended, hpa.SuspendedUntil),
+			ownershipAttributes(hpa.Tags, hpa.Owner, hpa.Contact),
+		)...),
+	}
+}
+
+// writeScalables writes the scalables, which must be sorted by file, and
+// returns the number of files written.
+func writeScalables(scalables []exportedScalable, outDir string, force bool) (int, error) {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return 0, err
+	}
+
+	files := 0
+	for start := 0; start < len(scalables); {
+		end := start
+		for end < len(scalables) && scalables[end].file == scalables[start].file {
+			end++
+		}
+		if err := writeScalablesFile(filepath.Join(outDir, scalables[start].file+".tf"), scalables[start:end], force); err != nil {
+			return files, err
+		}
+		files++
+		start = end
+	}
+	return files, nil
+}
+
+func writeScalablesFile(filename string, scalables []exportedScalable, force bool) (err error) {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(filename, flags, 0o644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return fmt.Errorf("%s already exists, use -force to overwrite it", filename)
+		}
+		return err
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	w := bufio.NewWriter(f)
+	for i, scalable := range scalables {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+
+		address := scalable.resourceType + "." + scalable.name
+		blocks := []hclBlock{
+			{
+				header: "import",
+				attributes: []hclAttribute{
+					{name: "to", value: address},
+					hclObjectAttribute("identity",
+						hclStringAttribute("scalable_id", scalable.scalableID),
+						hclStringAttribute("region", scalable.region),
+					),
+				},
+			},
+			{
+				header:     fmt.Sprintf("resource %s %s", hclString(scalable.resourceType), hclString(scalable.name)),
+				attributes: scalable.attributes,
+			},
+		}
+		for j, block := range blocks {
+			if j > 0 {
+				if _, err := fmt.Fprintln(w); err != nil {
+					return err
+				}
+			}
+			if err := block.write(w); err != nil {
+				return err
+			}
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,150 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// hclAttribute is an attribute of a generated HCL block. Either value holds a
+// literal expression, or object holds the attributes of an object value.
+type hclAttribute struct {
+	name   string
+	value  string
+	object []hclAttribute
+}
+
+// hclBlock is a top-level HCL block such as a resource or import block.
+type hclBlock struct {
+	header     string
+	attributes []hclAttribute
+}
+
+func hclStringAttribute(name string, value string) hclAttribute {
+	return hclAttribute{name: name, value: hclString(value)}
+}
+
+func hclIntAttribute(name string, value int64) hclAttribute {
+	return hclAttribute{name: name, value: strconv.FormatInt(value, 10)}
+}
+
+func hclBoolAttribute(name string, value bool) hclAttribute {
+	return hclAttribute{name: name, value: strconv.FormatBool(value)}
+}
+
+func hclObjectAttribute(name string, attributes ...hclAttribute) hclAttribute {
+	return hclAttribute{name: name, object: attributes}
+}
+
+// hclMapAttribute renders a map of strings as an object with sorted keys,
+// quoting keys that are not valid identifiers.
+func hclMapAttribute(name string, values map[string]string) hclAttribute {
+	keys := slices.Sorted(maps.Keys(values))
+	attributes := make([]hclAttribute, 0, len(keys))
+	for _, key := range keys {
+		if !hclIdentifier.MatchString(key) {
+			attributes = append(attributes, hclAttribute{name: hclString(key), value: hclString(values[key])})
+			continue
+		}
+		attributes = append(attributes, hclStringAttribute(key, values[key]))
+	}
+	return hclObjectAttribute(name, attributes...)
+}
+
+// write renders the block the way terraform fmt would.
+func (b hclBlock) write(w io.Writer) error {
+	if _, err := fmt.Fprintf(w, "%s {\n", b.header); err != nil {
+		return err
+	}
+	if err := writeHclAttributes(w, b.attributes, 1); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// writeHclAttributes renders attributes at the given indentation level,
+// aligning the equals signs of consecutive single-line attributes.
+func writeHclAttributes(w io.Writer, attributes []hclAttribute, level int) error {
+	indent := strings.Repeat("  ", level)
+
+	width := 0
+	for i, attribute := range attributes {
+		if attribute.object != nil {
+			width = 0
+			if _, err := fmt.Fprintf(w, "%s%s = {\n", indent, attribute.name); err != nil {
+				return err
+			}
+			if err := writeHclAttributes(w, attribute.object, level+1); err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "%s}\n", indent); err != nil {
+				return err
+			}
+			continue
+		}
+		if width == 0 {
+			for _, next := range attributes[i:] {
+				if next.object != nil {
+					break
+				}
+				width = max(width, len(next.name))
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, "%s%-*s = %s\n", indent, width, attribute.name, attribute.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hclString quotes s as an HCL string literal, escaping template sequences so
+// the value is taken literally.
+func hclString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+var hclIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+var hclInvalidNameChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// hclName turns s into a valid Terraform resource name.
+func hclName(s string) string {
+	name := strings.Trim(hclInvalidNameChars.ReplaceAllString(strings.ToLower(s), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
@@ -5,11 +5,16 @@ package client
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
+	"sort"
 )
 
 type SssClient struct {
@@ -17,41 +22,153 @@ type SssClient struct {
 	protocol     string
 	authUsername string
 	authPassword string
+	endpoints    map[string]string
+	dryRun       bool
+	tlsConfig    *tls.Config
+	proxyURL     *url.URL
+	unresolved   bool
 	httpClient   *http.Client
 }
 
+// SssClientOption configures optional behaviour of an SssClient.
+type SssClientOption func(*SssClient)
+
+// WithEndpoints routes requests for scalables in the given regions to a
+// regional SSS host instead of the default host.
+func WithEndpoints(endpoints map[string]string) SssClientOption {
+	return func(client *SssClient) {
+		client.endpoints = endpoints
+	}
+}
+
 // NewSssClient creates a new client for the Scheduled Scaling Service API.
-func NewSssClient(host string, protocol string, authUsername string, authPassword string) *SssClient {
+func NewSssClient(host string, protocol string, authUsername string, authPassword string, opts ...SssClientOption) *SssClient {
 	httpClient := &http.Client{}
 
-	return &SssClient{
+	client := &SssClient{
 		host:         host,
 		protocol:     protocol,
 		authUsername: authUsername,
 		authPassword: authPassword,
 		httpClient:   httpClient,
 	}
+	for _, opt := range opts {
+		opt(client)
+	}
+	var transport http.RoundTripper = http.DefaultTransport
+	if defaultTransport, ok := http.DefaultTransport.(*http.Transport); ok && (client.tlsConfig != nil || client.proxyURL != nil) {
+		configured := defaultTransport.Clone()
+		if client.tlsConfig != nil {
+			configured.TLSClientConfig = client.tlsConfig
+		}
+		if client.proxyURL != nil {
+			configured.Proxy = http.ProxyURL(client.proxyURL)
+		}
+		transport = configured
+	}
+	if client.unresolved {
+		transport = unresolvedTransport{}
+	}
+	if client.dryRun {
+		transport = dryRunTransport{next: transport}
+	}
+	client.httpClient.Transport = loggingTransport{next: transport}
+	return client
+}
+
+// HostForRegion returns the SSS host that manages scalables in region.
+func (client *SssClient) HostForRegion(region string) string {
+	if host, ok := client.endpoints[region]; ok {
+		return host
+	}
+	return client.host
+}
+
+// HasEndpoints reports whether any regional endpoints are configured.
+func (client *SssClient) HasEndpoints() bool {
+	return len(client.endpoints) > 0
+}
+
+// ForRegion returns a client that sends requests to the SSS host configured
+// for region, or to the default host if the region has no endpoint of its own.
+func (client *SssClient) ForRegion(region string) *SssClient {
+	host := client.HostForRegion(region)
+	if host == client.host {
+		return client
+	}
+	regional := *client
+	regional.host = host
+	return &regional
 }
 
-type scalableType string
+// ForHost returns a client that sends requests to host, regardless of the
+// region of the scalable.
+func (client *SssClient) ForHost(host string) *SssClient {
+	if host == client.host {
+		return client
+	}
+	pinned := *client
+	pinned.host = host
+	return &pinned
+}
 
-const scalableTypeECS scalableType = "ecs"
-const scalableTypeDynamoDB scalableType = "dynamodbtable"
-const scalableTypeEKSHPA scalableType = "eks-hpa"
+// ForAllEndpoints returns a client for the default host followed by one for
+// each distinct regional endpoint, ordered by region.
+func (client *SssClient) ForAllEndpoints() []*SssClient {
+	regions := make([]string, 0, len(client.endpoints))
+	for region := range client.endpoints {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
 
-func getOrDeleteScalable[T any](client *SssClient, scalableType scalableType, scalableId string, method string) (*T, error) {
-	url := url.URL{
-		Scheme: client.protocol,
-		Host:   client.host,
-		Path:   path.Join("/api/v1/services/", string(scalableType), url.PathEscape(scalableId)),
+	clients := []*SssClient{client}
+	seen := map[string]bool{client.host: true}
+	for _, region := range regions {
+		if seen[client.endpoints[region]] {
+			continue
+		}
+		seen[client.endpoints[region]] = true
+		clients = append(clients, client.ForRegion(region))
 	}
-	req, err := http.NewRequest(method, url.String(), nil)
+	return clients
+}
+
+type ScalableType string
+
+const ScalableTypeECS ScalableType = "ecs"
+const ScalableTypeDynamoDB ScalableType = "dynamodbtable"
+const ScalableTypeEKSHPA ScalableType = "eks-hpa"
+
+// ErrNotFound is returned, wrapped, when SSS has no registration for a scalable.
+var ErrNotFound = errors.New("scalable not found")
+
+// do sends a request to an SSS API path on the host of the client, with body
+// encoded as JSON unless it is nil. The caller closes the response body.
+func (client *SssClient) do(ctx context.Context, method string, apiPath string, body any) (*http.Response, error) {
+	url := client.apiURL(apiPath)
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url.String(), reader)
 	if err != nil {
 		return nil, err
 	}
 	req.SetBasicAuth(client.authUsername, client.authPassword)
 	req.Header.Set("Accept", "application/json, application/problem+json")
-	response, err := client.httpClient.Do(req)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return client.httpClient.Do(req)
+}
+
+func getOrDeleteScalable[T any](ctx context.Context, client *SssClient, scalableType ScalableType, scalableId string, method string) (*T, error) {
+	response, err := client.do(ctx, method, path.Join("/api/v1/services/", string(scalableType), url.PathEscape(scalableId)), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +179,9 @@ func getOrDeleteScalable[T any](client *SssClient, scalableType scalableType, sc
 		}
 		return nil, nil
 	}
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get scalable %s/%s: %s: %w", string(scalableType), scalableId, response.Status, ErrNotFound)
+	}
 	if response.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("failed to get scalable %s/%s: %s", string(scalableType), scalableId, response.Status)
 	}
@@ -74,25 +194,25 @@ func getOrDeleteScalable[T any](client *SssClient, scalableType scalableType, sc
 	return &scalableResponse, nil
 }
 
-func editScalable[T any](client *SssClient, scalableType scalableType, scalableId string, capacities T, method string) error {
-	url := url.URL{
-		Scheme: client.protocol,
-		Host:   client.host,
-		Path:   path.Join("/api/v1/services/", string(scalableType), url.PathEscape(scalableId)),
-	}
-
-	body, err := json.Marshal(capacities)
+func listScalables[T any](ctx context.Context, client *SssClient, scalableType ScalableType) ([]T, error) {
+	response, err := client.do(ctx, "GET", path.Join("/api/v1/services/", string(scalableType)), nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list scalables %s: %s", string(scalableType), response.Status)
 	}
-	req, err := http.NewRequest(method, url.String(), bytes.NewBuffer(body))
+	var scalables []T
+	err = json.NewDecoder(response.Body).Decode(&scalables)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	req.SetBasicAuth(client.authUsername, client.authPassword)
-	req.Header.Set("Accept", "application/json, application/problem+json")
-	req.Header.Set("Content-Type", "application/json")
-	response, err := client.httpClient.Do(req)
+	return scalables, nil
+}
+
+func editScalable[T any](ctx context.Context, client *SssClient, scalableType ScalableType, scalableId string, capacities T, method string) error {
+	response, err := client.do(ctx, method, path.Join("/api/v1/services/", string(scalableType), url.PathEscape(scalableId)), capacities)
 	if err != nil {
 		return err
 	}
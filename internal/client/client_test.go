@@ -0,0 +1,61 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDo(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		body            any
+		wantBody        string
+		wantContentType string
+	}{
+		{name: "without body", method: "GET"},
+		{name: "with body", method: "POST", body: map[string]int{"minLowCapacity": 1}, wantBody: `{"minLowCapacity":1}`, wantContentType: "application/json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var gotBody string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				got, gotBody = r, string(body)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			client := NewSssClient(strings.TrimPrefix(server.URL, "http://")+"/sss", "http", "user", "secret")
+			response, err := client.do(context.Background(), tt.method, "/api/v1/services/ecs", tt.body)
+			if err != nil {
+				t.Fatalf("do() returned error: %v", err)
+			}
+			_ = response.Body.Close()
+
+			if got.Method != tt.method || got.URL.Path != "/sss/api/v1/services/ecs" {
+				t.Fatalf("do() sent %s %s, want %s /sss/api/v1/services/ecs", got.Method, got.URL.Path, tt.method)
+			}
+			if username, password, ok := got.BasicAuth(); !ok || username != "user" || password != "secret" {
+				t.Fatalf("do() sent basic auth %q:%q, want user:secret", username, password)
+			}
+			if accept := got.Header.Get("Accept"); accept != "application/json, application/problem+json" {
+				t.Fatalf("do() sent Accept %q", accept)
+			}
+			if contentType := got.Header.Get("Content-Type"); contentType != tt.wantContentType {
+				t.Fatalf("do() sent Content-Type %q, want %q", contentType, tt.wantContentType)
+			}
+			if gotBody != tt.wantBody {
+				t.Fatalf("do() sent body %q, want %q", gotBody, tt.wantBody)
+			}
+		})
+	}
+}
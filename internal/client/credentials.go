@@ -0,0 +1,140 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultProfile is the credentials file profile used when none is given.
+const DefaultProfile = "default"
+
+// Credentials are the basicauth credentials of a credentials file profile.
+type Credentials struct {
+	AuthUsername string `json:"auth_username"`
+	AuthPassword string `json:"auth_password"`
+}
+
+// DefaultCredentialsFile returns ~/.sss/credentials, or "" if the home
+// directory cannot be determined.
+func DefaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".sss", "credentials")
+}
+
+// FillCredentials fills in the username and password, when either is empty,
+// from a credentials file profile. filename and profile default to
+// DefaultCredentialsFile and DefaultProfile, and a missing default file is
+// ignored unless a profile is given.
+func FillCredentials(credentials *Credentials, filename string, profile string) error {
+	if credentials.AuthUsername != "" && credentials.AuthPassword != "" {
+		return nil
+	}
+
+	optional := filename == "" && profile == ""
+	if filename == "" {
+		filename = DefaultCredentialsFile()
+	} else if strings.HasPrefix(filename, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			filename = filepath.Join(home, filename[2:])
+		}
+	}
+	if profile == "" {
+		profile = DefaultProfile
+	}
+
+	fromFile, err := LoadCredentials(filename, profile)
+	if err != nil {
+		if optional && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	if credentials.AuthUsername == "" {
+		credentials.AuthUsername = fromFile.AuthUsername
+	}
+	if credentials.AuthPassword == "" {
+		credentials.AuthPassword = fromFile.AuthPassword
+	}
+	return nil
+}
+
+// LoadCredentials reads a profile from a credentials file. The file is either
+// a JSON object keyed by profile name, or an INI file with one section per
+// profile, like the AWS shared credentials file:
+//
+//	[default]
+//	auth_username = terraform
+//	auth_password = secret
+func LoadCredentials(filename string, profile string) (*Credentials, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var profiles map[string]Credentials
+	if trimmed := bytes.TrimSpace(content); len(trimmed) > 0 && trimmed[0] == '{' {
+		if err := json.Unmarshal(content, &profiles); err != nil {
+			return nil, fmt.Errorf("failed to parse credentials file %s: %w", filename, err)
+		}
+	} else {
+		profiles, err = parseCredentialsIni(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse credentials file %s: %w", filename, err)
+		}
+	}
+
+	credentials, ok := profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in credentials file %s", profile, filename)
+	}
+	return &credentials, nil
+}
+
+func parseCredentialsIni(content []byte) (map[string]Credentials, error) {
+	profiles := map[string]Credentials{}
+	profile := ""
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text[0] == '#' || text[0] == ';' {
+			continue
+		}
+		if strings.HasPrefix(text, "[") && strings.HasSuffix(text, "]") {
+			profile = strings.TrimSpace(strings.TrimPrefix(text[1:len(text)-1], "profile "))
+			profiles[profile] = profiles[profile]
+			continue
+		}
+
+		key, value, found := strings.Cut(text, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected key = value", line)
+		}
+		if profile == "" {
+			return nil, fmt.Errorf("line %d: %s is not in a [profile] section", line, strings.TrimSpace(key))
+		}
+		credentials := profiles[profile]
+		switch strings.TrimSpace(key) {
+		case "auth_username":
+			credentials.AuthUsername = strings.TrimSpace(value)
+		case "auth_password":
+			credentials.AuthPassword = strings.TrimSpace(value)
+		}
+		profiles[profile] = credentials
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
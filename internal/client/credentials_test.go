@@ -0,0 +1,117 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testCredentialsIni = `# SSS credentials
+[default]
+auth_username = terraform
+auth_password = secret
+
+[profile ci]
+auth_username = ci
+; no password
+`
+
+const testCredentialsJSON = `{
+  "default": {"auth_username": "terraform", "auth_password": "secret"},
+  "ci": {"auth_username": "ci"}
+}`
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+	filename := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return filename
+}
+
+func TestLoadCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		profile string
+		want    Credentials
+		wantErr string
+	}{
+		{name: "ini default", content: testCredentialsIni, profile: "default", want: Credentials{AuthUsername: "terraform", AuthPassword: "secret"}},
+		{name: "ini profile prefix", content: testCredentialsIni, profile: "ci", want: Credentials{AuthUsername: "ci"}},
+		{name: "ini missing profile", content: testCredentialsIni, profile: "prod", wantErr: `profile "prod" not found`},
+		{name: "ini without equals", content: "[default]\nauth_username\n", profile: "default", wantErr: "line 2: expected key = value"},
+		{name: "ini without section", content: "auth_username = terraform\n", profile: "default", wantErr: "line 1: auth_username is not in a [profile] section"},
+		{name: "json default", content: testCredentialsJSON, profile: "default", want: Credentials{AuthUsername: "terraform", AuthPassword: "secret"}},
+		{name: "json partial profile", content: testCredentialsJSON, profile: "ci", want: Credentials{AuthUsername: "ci"}},
+		{name: "json missing profile", content: testCredentialsJSON, profile: "prod", wantErr: `profile "prod" not found`},
+		{name: "json malformed", content: `{"default": `, profile: "default", wantErr: "failed to parse credentials file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadCredentials(writeCredentialsFile(t, tt.content), tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadCredentials() = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadCredentials() returned error: %v", err)
+			}
+			if *got != tt.want {
+				t.Fatalf("LoadCredentials() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillCredentials(t *testing.T) {
+	filename := writeCredentialsFile(t, testCredentialsIni)
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name        string
+		credentials Credentials
+		filename    string
+		profile     string
+		home        string
+		want        Credentials
+		wantErr     string
+	}{
+		{name: "complete credentials skip the file", credentials: Credentials{AuthUsername: "u", AuthPassword: "p"}, filename: missing, want: Credentials{AuthUsername: "u", AuthPassword: "p"}},
+		{name: "file fills both", filename: filename, want: Credentials{AuthUsername: "terraform", AuthPassword: "secret"}},
+		{name: "configured username wins", credentials: Credentials{AuthUsername: "u"}, filename: filename, want: Credentials{AuthUsername: "u", AuthPassword: "secret"}},
+		{name: "profile", filename: filename, profile: "ci", want: Credentials{AuthUsername: "ci"}},
+		{name: "missing profile", filename: filename, profile: "prod", wantErr: `profile "prod" not found`},
+		{name: "missing explicit file", filename: missing, wantErr: "no such file"},
+		{name: "missing default file is ignored", home: t.TempDir()},
+		{name: "missing default file with profile", home: t.TempDir(), profile: "ci", wantErr: "no such file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.home != "" {
+				t.Setenv("HOME", tt.home)
+			}
+			credentials := tt.credentials
+			err := FillCredentials(&credentials, tt.filename, tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("FillCredentials() = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FillCredentials() returned error: %v", err)
+			}
+			if credentials != tt.want {
+				t.Fatalf("FillCredentials() = %+v, want %+v", credentials, tt.want)
+			}
+		})
+	}
+}
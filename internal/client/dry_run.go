@@ -0,0 +1,69 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// WithDryRun makes the client log the requests that would modify SSS instead
+// of sending them, answering them as SSS does on success. GET requests are
+// still sent.
+func WithDryRun(dryRun bool) SssClientOption {
+	return func(client *SssClient) {
+		client.dryRun = dryRun
+	}
+}
+
+// DryRun reports whether the client only logs requests that would modify SSS.
+func (client *SssClient) DryRun() bool {
+	return client.dryRun
+}
+
+// dryRunTransport sends GET requests through next, and logs all other
+// requests instead of sending them. It is wrapped by loggingTransport, which
+// sets up the log subsystem and the method field.
+type dryRunTransport struct {
+	next http.RoundTripper
+}
+
+func (t dryRunTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+
+	body := ""
+	if req.Body != nil {
+		defer func() { _ = req.Body.Close() }()
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = string(b)
+	}
+	tflog.SubsystemInfo(req.Context(), logSubsystem, "Dry run, not sending request to SSS", map[string]any{
+		"host": req.URL.Host,
+		"path": req.URL.EscapedPath(),
+		"body": body,
+	})
+
+	status := http.StatusOK
+	if req.Method == http.MethodPost {
+		status = http.StatusCreated
+	}
+	return &http.Response{
+		Status:     http.StatusText(status),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader("{}")),
+		Request:    req,
+	}, nil
+}
@@ -3,18 +3,24 @@
 
 package client
 
-func (client *SssClient) GetDynamoTable(tableArn string) (*DynamoTableResponse, error) {
-	return getOrDeleteScalable[DynamoTableResponse](client, scalableTypeDynamoDB, tableArn, "GET")
+import "context"
+
+func (client *SssClient) GetDynamoTable(ctx context.Context, tableArn string) (*DynamoTableResponse, error) {
+	return getOrDeleteScalable[DynamoTableResponse](ctx, client, ScalableTypeDynamoDB, tableArn, "GET")
+}
+
+func (client *SssClient) CreateDynamoTable(ctx context.Context, tableArn string, capacities DynamoTablePostBody) error {
+	return editScalable(ctx, client, ScalableTypeDynamoDB, tableArn, capacities, "POST")
 }
 
-func (client *SssClient) CreateDynamoTable(tableArn string, capacities DynamoTablePostBody) error {
-	return editScalable(client, scalableTypeDynamoDB, tableArn, capacities, "POST")
+func (client *SssClient) UpdateDynamoTable(ctx context.Context, tableArn string, capacities DynamoTablePostBody) error {
+	return editScalable(ctx, client, ScalableTypeDynamoDB, tableArn, capacities, "PUT")
 }
 
-func (client *SssClient) UpdateDynamoTable(tableArn string, capacities DynamoTablePostBody) error {
-	return editScalable(client, scalableTypeDynamoDB, tableArn, capacities, "PUT")
+func (client *SssClient) DeleteDynamoTable(ctx context.Context, tableArn string) (*DynamoTableResponse, error) {
+	return getOrDeleteScalable[DynamoTableResponse](ctx, client, ScalableTypeDynamoDB, tableArn, "DELETE")
 }
 
-func (client *SssClient) DeleteDynamoTable(tableArn string) (*DynamoTableResponse, error) {
-	return getOrDeleteScalable[DynamoTableResponse](client, scalableTypeDynamoDB, tableArn, "DELETE")
+func (client *SssClient) ListDynamoTables(ctx context.Context) ([]DynamoTableResponse, error) {
+	return listScalables[DynamoTableResponse](ctx, client, ScalableTypeDynamoDB)
 }
@@ -3,18 +3,24 @@
 
 package client
 
-func (client *SssClient) GetEcsService(serviceName string) (*EcsServiceResponse, error) {
-	return getOrDeleteScalable[EcsServiceResponse](client, scalableTypeECS, serviceName, "GET")
+import "context"
+
+func (client *SssClient) GetEcsService(ctx context.Context, serviceName string) (*EcsServiceResponse, error) {
+	return getOrDeleteScalable[EcsServiceResponse](ctx, client, ScalableTypeECS, serviceName, "GET")
+}
+
+func (client *SssClient) CreateEcsService(ctx context.Context, serviceName string, capacities EcsServicePostBody) error {
+	return editScalable(ctx, client, ScalableTypeECS, serviceName, capacities, "POST")
 }
 
-func (client *SssClient) CreateEcsService(serviceName string, capacities EcsServicePostBody) error {
-	return editScalable(client, scalableTypeECS, serviceName, capacities, "POST")
+func (client *SssClient) UpdateEcsService(ctx context.Context, serviceName string, capacities EcsServicePostBody) error {
+	return editScalable(ctx, client, ScalableTypeECS, serviceName, capacities, "PUT")
 }
 
-func (client *SssClient) UpdateEcsService(serviceName string, capacities EcsServicePostBody) error {
-	return editScalable(client, scalableTypeECS, serviceName, capacities, "PUT")
+func (client *SssClient) DeleteEcsService(ctx context.Context, serviceName string) (*EcsServiceResponse, error) {
+	return getOrDeleteScalable[EcsServiceResponse](ctx, client, ScalableTypeECS, serviceName, "DELETE")
 }
 
-func (client *SssClient) DeleteEcsService(serviceName string) (*EcsServiceResponse, error) {
-	return getOrDeleteScalable[EcsServiceResponse](client, scalableTypeECS, serviceName, "DELETE")
+func (client *SssClient) ListEcsServices(ctx context.Context) ([]EcsServiceResponse, error) {
+	return listScalables[EcsServiceResponse](ctx, client, ScalableTypeECS)
 }
@@ -3,18 +3,24 @@
 
 package client
 
-func (client *SssClient) GetEksHpa(serviceId string) (*EksHpaResponse, error) {
-	return getOrDeleteScalable[EksHpaResponse](client, scalableTypeEKSHPA, serviceId, "GET")
+import "context"
+
+func (client *SssClient) GetEksHpa(ctx context.Context, serviceId string) (*EksHpaResponse, error) {
+	return getOrDeleteScalable[EksHpaResponse](ctx, client, ScalableTypeEKSHPA, serviceId, "GET")
+}
+
+func (client *SssClient) CreateEksHpa(ctx context.Context, serviceId string, body EksHpaPostBody) error {
+	return editScalable(ctx, client, ScalableTypeEKSHPA, serviceId, body, "POST")
 }
 
-func (client *SssClient) CreateEksHpa(serviceId string, body EksHpaPostBody) error {
-	return editScalable(client, scalableTypeEKSHPA, serviceId, body, "POST")
+func (client *SssClient) UpdateEksHpa(ctx context.Context, serviceId string, body EksHpaPostBody) error {
+	return editScalable(ctx, client, ScalableTypeEKSHPA, serviceId, body, "PUT")
 }
 
-func (client *SssClient) UpdateEksHpa(serviceId string, body EksHpaPostBody) error {
-	return editScalable(client, scalableTypeEKSHPA, serviceId, body, "PUT")
+func (client *SssClient) DeleteEksHpa(ctx context.Context, serviceId string) (*EksHpaResponse, error) {
+	return getOrDeleteScalable[EksHpaResponse](ctx, client, ScalableTypeEKSHPA, serviceId, "DELETE")
 }
 
-func (client *SssClient) DeleteEksHpa(serviceId string) (*EksHpaResponse, error) {
-	return getOrDeleteScalable[EksHpaResponse](client, scalableTypeEKSHPA, serviceId, "DELETE")
+func (client *SssClient) ListEksHpas(ctx context.Context) ([]EksHpaResponse, error) {
+	return listScalables[EksHpaResponse](ctx, client, ScalableTypeEKSHPA)
 }
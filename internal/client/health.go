@@ -0,0 +1,31 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrUnauthorized is returned, wrapped, when SSS rejects the credentials.
+var ErrUnauthorized = errors.New("SSS rejected the credentials")
+
+// CheckHealth checks that SSS is reachable on the host of the client and
+// accepts its credentials.
+func (client *SssClient) CheckHealth(ctx context.Context) error {
+	response, err := client.do(ctx, "GET", "/api/v1/health", nil)
+	if err != nil {
+		return fmt.Errorf("failed to reach SSS at %s: %w", client.host, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("failed to authenticate to SSS at %s: %s: %w", client.host, response.Status, ErrUnauthorized)
+	}
+	return fmt.Errorf("SSS at %s is unhealthy: %s", client.host, response.Status)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ValidateHost checks that host is a host name or IP address with an optional
+// port and base path, such as sss.example.com:8443/sss, and no protocol,
+// credentials, query or fragment.
+func ValidateHost(host string) error {
+	if host == "" {
+		return errors.New("the host must not be empty")
+	}
+	if strings.Contains(host, "://") {
+		return fmt.Errorf("the host %q must not include the protocol", host)
+	}
+	if strings.ContainsAny(host, "?#@") {
+		return fmt.Errorf("the host %q must not include credentials, a query or a fragment", host)
+	}
+	parsed, err := url.Parse("//" + host)
+	if err != nil {
+		return fmt.Errorf("invalid host %q: %w", host, err)
+	}
+	if parsed.Hostname() == "" {
+		return fmt.Errorf("the host %q is missing a host name", host)
+	}
+	return nil
+}
+
+// apiURL returns the URL of an SSS API path on the host of the client, below
+// the base path of the host if it has one.
+func (client *SssClient) apiURL(apiPath string) url.URL {
+	host, basePath, _ := strings.Cut(client.host, "/")
+	return url.URL{
+		Scheme: client.protocol,
+		Host:   host,
+		Path:   path.Join("/", basePath, apiPath),
+	}
+}
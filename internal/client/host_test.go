@@ -0,0 +1,64 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateHost(t *testing.T) {
+	tests := []struct {
+		host    string
+		wantErr string
+	}{
+		{host: "sss.example.com"},
+		{host: "sss.example.com:8443"},
+		{host: "sss.example.com:8443/sss"},
+		{host: "10.0.0.1"},
+		{host: "[::1]:8080"},
+		{host: "", wantErr: "must not be empty"},
+		{host: "https://sss.example.com", wantErr: "must not include the protocol"},
+		{host: "user:pass@sss.example.com", wantErr: "must not include credentials"},
+		{host: "sss.example.com?debug=1", wantErr: "must not include credentials, a query or a fragment"},
+		{host: "sss.example.com#top", wantErr: "must not include credentials, a query or a fragment"},
+		{host: "sss.example.com:port", wantErr: "invalid host"},
+		{host: ":8443", wantErr: "missing a host name"},
+		{host: "/sss", wantErr: "missing a host name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			err := ValidateHost(tt.host)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ValidateHost(%q) returned error: %v", tt.host, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ValidateHost(%q) = %v, want error containing %q", tt.host, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApiURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "sss.example.com", want: "https://sss.example.com/api/v1/health"},
+		{host: "sss.example.com:8443/sss", want: "https://sss.example.com:8443/sss/api/v1/health"},
+		{host: "sss.example.com/sss/", want: "https://sss.example.com/sss/api/v1/health"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			client := &SssClient{host: tt.host, protocol: "https"}
+			url := client.apiURL("/api/v1/health")
+			if got := url.String(); got != tt.want {
+				t.Fatalf("apiURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
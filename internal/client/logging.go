@@ -0,0 +1,125 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem of the client. Its level follows the
+// provider log level, or TF_LOG_PROVIDER_SSS_CLIENT when set, and its entries
+// carry the fields of the provider logger, such as the scalable set by the
+// resources.
+const logSubsystem = "sss_client"
+
+// maxLoggedBodyLength is how much of a request or response body is logged.
+const maxLoggedBodyLength = 1024
+
+// omittedBody replaces the bodies of requests to the API token endpoints,
+// whose responses hold API tokens.
+const omittedBody = "(omitted, may hold an API token)"
+
+// loggingTransport logs each request sent through next, with its response, at
+// the debug level, and their bodies at the trace level.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_SSS_CLIENT"), tflog.WithRootFields())
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "http_method", req.Method)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, "http_url", req.URL.String())
+	req = req.WithContext(ctx)
+
+	sensitive := strings.Contains(req.URL.Path, "/api/v1/tokens")
+	trace := traceEnabled()
+	fields := map[string]any{"authorization": maskAuthorization(req.Header.Get("Authorization"))}
+	if sensitive {
+		fields["request_body"] = omittedBody
+	} else if trace && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			fields["request_body"] = readLoggedBody(body)
+		}
+	}
+	tflog.SubsystemTrace(ctx, logSubsystem, "Sending request to SSS", fields)
+
+	start := time.Now()
+	response, err := t.next.RoundTrip(req)
+	latency := time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.SubsystemDebug(ctx, logSubsystem, "Request to SSS failed", map[string]any{
+			"latency_ms": latency,
+			"error":      err.Error(),
+		})
+		return nil, err
+	}
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "Received response from SSS", map[string]any{
+		"http_status": response.StatusCode,
+		"latency_ms":  latency,
+	})
+	if sensitive {
+		tflog.SubsystemTrace(ctx, logSubsystem, "Response body from SSS", map[string]any{
+			"response_body": omittedBody,
+		})
+	} else if trace && response.Body != nil {
+		body, err := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		response.Body = io.NopCloser(bytes.NewReader(body))
+		tflog.SubsystemTrace(ctx, logSubsystem, "Response body from SSS", map[string]any{
+			"response_body": truncateLoggedBody(string(body)),
+		})
+	}
+	return response, nil
+}
+
+// traceEnabled reports whether the client logs at the trace level, following
+// TF_LOG_PROVIDER_SSS_CLIENT, then TF_LOG_PROVIDER, then TF_LOG. tflog cannot
+// tell whether a level is enabled, and a response body has to be read into
+// memory to be logged, so bodies are only read when this returns true.
+func traceEnabled() bool {
+	for _, name := range []string{"TF_LOG_PROVIDER_SSS_CLIENT", "TF_LOG_PROVIDER", "TF_LOG"} {
+		if level := strings.ToUpper(os.Getenv(name)); level != "" {
+			return level == "TRACE" || level == "JSON"
+		}
+	}
+	return false
+}
+
+// maskAuthorization keeps only the scheme of an Authorization header.
+func maskAuthorization(header string) string {
+	if header == "" {
+		return ""
+	}
+	scheme, _, _ := strings.Cut(header, " ")
+	return scheme + " ***"
+}
+
+// readLoggedBody reads and closes a request body for logging.
+func readLoggedBody(body io.ReadCloser) string {
+	defer func() { _ = body.Close() }()
+	b, err := io.ReadAll(io.LimitReader(body, maxLoggedBodyLength+1))
+	if err != nil {
+		return ""
+	}
+	return truncateLoggedBody(string(b))
+}
+
+// truncateLoggedBody shortens a body to maxLoggedBodyLength bytes.
+func truncateLoggedBody(body string) string {
+	if len(body) <= maxLoggedBodyLength {
+		return body
+	}
+	return body[:maxLoggedBodyLength] + "...(truncated)"
+}
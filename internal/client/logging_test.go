@@ -0,0 +1,112 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+// roundTripFunc is an http.RoundTripper that calls itself.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestLoggingTransport(t *testing.T) {
+	tests := []struct {
+		name          string
+		level         string
+		path          string
+		authorization string
+		requestBody   string
+		responseBody  string
+		wantLogged    []string
+		wantOmitted   []string
+		wantBuffered  bool
+	}{
+		{
+			name:          "basic credentials",
+			level:         "TRACE",
+			path:          "/api/v1/services/ecs/service%2Fcluster%2Fapp",
+			authorization: "Basic dXNlcjpzZWNyZXQ=",
+			requestBody:   `{"minLowCapacity":1}`,
+			responseBody:  `{"name":"service/cluster/app"}`,
+			wantLogged:    []string{`"authorization":"Basic ***"`, `"request_body":"{\"minLowCapacity\":1}"`, `"response_body":"{\"name\":\"service/cluster/app\"}"`},
+			wantOmitted:   []string{"dXNlcjpzZWNyZXQ=", "retry_count"},
+			wantBuffered:  true,
+		},
+		{
+			name:          "api token",
+			level:         "TRACE",
+			path:          "/api/v1/tokens",
+			authorization: "Bearer sss_existing-token",
+			requestBody:   `{"name":"ci"}`,
+			responseBody:  `{"token":"sss_new-token"}`,
+			wantLogged:    []string{`"authorization":"Bearer ***"`, `"request_body":"` + omittedBody + `"`, `"response_body":"` + omittedBody + `"`},
+			wantOmitted:   []string{"sss_existing-token", "sss_new-token", `"name":"ci"`},
+		},
+		{
+			name:          "trace disabled",
+			level:         "DEBUG",
+			path:          "/api/v1/services/ecs/service%2Fcluster%2Fapp",
+			authorization: "Basic dXNlcjpzZWNyZXQ=",
+			requestBody:   `{"minLowCapacity":1}`,
+			responseBody:  `{"name":"service/cluster/app"}`,
+			wantLogged:    []string{`"http_status":200`},
+			wantOmitted:   []string{"dXNlcjpzZWNyZXQ=", "request_body", "response_body"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TF_LOG_PROVIDER_SSS_CLIENT", tt.level)
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			sent := io.NopCloser(strings.NewReader(tt.responseBody))
+			transport := loggingTransport{next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Body: sent, Request: req}, nil
+			})}
+			req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://sss.example.com"+tt.path, strings.NewReader(tt.requestBody))
+			if err != nil {
+				t.Fatal(err)
+			}
+			req.Header.Set("Authorization", tt.authorization)
+
+			response, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() returned error: %v", err)
+			}
+			if buffered := response.Body != sent; buffered != tt.wantBuffered {
+				t.Errorf("response body buffered = %t, want %t", buffered, tt.wantBuffered)
+			}
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != tt.responseBody {
+				t.Fatalf("response body = %q, want %q", body, tt.responseBody)
+			}
+
+			logged := output.String()
+			for _, want := range tt.wantLogged {
+				if !strings.Contains(logged, want) {
+					t.Errorf("log does not contain %s:\n%s", want, logged)
+				}
+			}
+			for _, omitted := range tt.wantOmitted {
+				if strings.Contains(logged, omitted) {
+					t.Errorf("log contains %s:\n%s", omitted, logged)
+				}
+			}
+		})
+	}
+}
@@ -3,21 +3,33 @@
 
 package client
 
+import "time"
+
 type EcsServicePostBody struct {
-	MinExtremeCapacity int64  `json:"minExtremeCapacity"`
-	MinHighCapacity    int64  `json:"minHighCapacity"`
-	MinMediumCapacity  int64  `json:"minMediumCapacity"`
-	MinLowCapacity     int64  `json:"minLowCapacity"`
-	Region             string `json:"region"`
+	MinExtremeCapacity int64             `json:"minExtremeCapacity"`
+	MinHighCapacity    int64             `json:"minHighCapacity"`
+	MinMediumCapacity  int64             `json:"minMediumCapacity"`
+	MinLowCapacity     int64             `json:"minLowCapacity"`
+	Region             string            `json:"region"`
+	Suspended          bool              `json:"suspended"`
+	SuspendedUntil     *time.Time        `json:"suspendedUntil,omitempty"`
+	Tags               map[string]string `json:"tags,omitempty"`
+	Owner              string            `json:"owner,omitempty"`
+	Contact            string            `json:"contact,omitempty"`
 }
 
 type EcsServiceResponse struct {
-	Name               string `json:"name"`
-	MinExtremeCapacity int64  `json:"minExtremeCapacity"`
-	MinHighCapacity    int64  `json:"minHighCapacity"`
-	MinMediumCapacity  int64  `json:"minMediumCapacity"`
-	MinLowCapacity     int64  `json:"minLowCapacity"`
-	Region             string `json:"region"`
+	Name               string            `json:"name"`
+	MinExtremeCapacity int64             `json:"minExtremeCapacity"`
+	MinHighCapacity    int64             `json:"minHighCapacity"`
+	MinMediumCapacity  int64             `json:"minMediumCapacity"`
+	MinLowCapacity     int64             `json:"minLowCapacity"`
+	Region             string            `json:"region"`
+	Suspended          bool              `json:"suspended"`
+	SuspendedUntil     *time.Time        `json:"suspendedUntil,omitempty"`
+	Tags               map[string]string `json:"tags,omitempty"`
+	Owner              string            `json:"owner,omitempty"`
+	Contact            string            `json:"contact,omitempty"`
 }
 
 type DynamoTableCapacity struct {
@@ -33,6 +45,11 @@ type DynamoTablePostBody struct {
 	MediumCapacity  DynamoTableCapacity `json:"mediumCapacity"`
 	HighCapacity    DynamoTableCapacity `json:"highCapacity"`
 	ExtremeCapacity DynamoTableCapacity `json:"extremeCapacity"`
+	Suspended       bool                `json:"suspended"`
+	SuspendedUntil  *time.Time          `json:"suspendedUntil,omitempty"`
+	Tags            map[string]string   `json:"tags,omitempty"`
+	Owner           string              `json:"owner,omitempty"`
+	Contact         string              `json:"contact,omitempty"`
 }
 
 type DynamoTableResponse struct {
@@ -42,31 +59,46 @@ type DynamoTableResponse struct {
 	MediumCapacity  DynamoTableCapacity `json:"mediumCapacity"`
 	HighCapacity    DynamoTableCapacity `json:"highCapacity"`
 	ExtremeCapacity DynamoTableCapacity `json:"extremeCapacity"`
+	Suspended       bool                `json:"suspended"`
+	SuspendedUntil  *time.Time          `json:"suspendedUntil,omitempty"`
+	Tags            map[string]string   `json:"tags,omitempty"`
+	Owner           string              `json:"owner,omitempty"`
+	Contact         string              `json:"contact,omitempty"`
 }
 
 type EksHpaPostBody struct {
-	Cluster    string `json:"cluster"`
-	Region     string `json:"region"`
-	Namespace  string `json:"namespace"`
-	Name       string `json:"name"`
-	Kind       string `json:"kind"`
-	MinLow     int64  `json:"minLow"`
-	MinMedium  int64  `json:"minMedium"`
-	MinHigh    int64  `json:"minHigh"`
-	MinExtreme int64  `json:"minExtreme"`
+	Cluster        string            `json:"cluster"`
+	Region         string            `json:"region"`
+	Namespace      string            `json:"namespace"`
+	Name           string            `json:"name"`
+	Kind           string            `json:"kind"`
+	MinLow         int64             `json:"minLow"`
+	MinMedium      int64             `json:"minMedium"`
+	MinHigh        int64             `json:"minHigh"`
+	MinExtreme     int64             `json:"minExtreme"`
+	Suspended      bool              `json:"suspended"`
+	SuspendedUntil *time.Time        `json:"suspendedUntil,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	Owner          string            `json:"owner,omitempty"`
+	Contact        string            `json:"contact,omitempty"`
 }
 
 type EksHpaResponse struct {
-	ID         string `json:"id"`
-	Cluster    string `json:"cluster"`
-	Region     string `json:"region"`
-	Namespace  string `json:"namespace"`
-	Name       string `json:"name"`
-	Kind       string `json:"kind"`
-	MinLow     int64  `json:"minLow"`
-	MinMedium  int64  `json:"minMedium"`
-	MinHigh    int64  `json:"minHigh"`
-	MinExtreme int64  `json:"minExtreme"`
+	ID             string            `json:"id"`
+	Cluster        string            `json:"cluster"`
+	Region         string            `json:"region"`
+	Namespace      string            `json:"namespace"`
+	Name           string            `json:"name"`
+	Kind           string            `json:"kind"`
+	MinLow         int64             `json:"minLow"`
+	MinMedium      int64             `json:"minMedium"`
+	MinHigh        int64             `json:"minHigh"`
+	MinExtreme     int64             `json:"minExtreme"`
+	Suspended      bool              `json:"suspended"`
+	SuspendedUntil *time.Time        `json:"suspendedUntil,omitempty"`
+	Tags           map[string]string `json:"tags,omitempty"`
+	Owner          string            `json:"owner,omitempty"`
+	Contact        string            `json:"contact,omitempty"`
 }
 
 type ErrorDetail struct {
@@ -83,3 +115,32 @@ type ErrorModel struct {
 	Title    string        `json:"title"`
 	Type     string        `json:"type"`
 }
+
+type LevelOverridePostBody struct {
+	Level           string `json:"level"`
+	DurationSeconds int64  `json:"durationSeconds,omitempty"`
+	Region          string `json:"region,omitempty"`
+}
+
+type ApiTokenPostBody struct {
+	Scopes     []string `json:"scopes"`
+	TtlSeconds int64    `json:"ttlSeconds,omitempty"`
+}
+
+type ApiTokenResponse struct {
+	ID        string    `json:"id"`
+	Token     string    `json:"token"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// ScalableStatusResponse is the capacity SSS has applied to a scalable for
+// its current level. EffectiveMin holds the minimum tasks or replicas of ECS
+// services and EKS HPAs, and EffectiveCapacity the capacity of DynamoDB tables.
+type ScalableStatusResponse struct {
+	CurrentLevel      string               `json:"currentLevel"`
+	EffectiveMin      int64                `json:"effectiveMin"`
+	EffectiveCapacity *DynamoTableCapacity `json:"effectiveCapacity,omitempty"`
+	LastAppliedAt     *time.Time           `json:"lastAppliedAt,omitempty"`
+	LastApplyStatus   string               `json:"lastApplyStatus"`
+}
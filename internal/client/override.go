@@ -0,0 +1,66 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// SetScalableLevel overrides the schedule of a scalable and switches it to a
+// level immediately, until the duration in the body has passed or the
+// override is cleared.
+func (client *SssClient) SetScalableLevel(ctx context.Context, scalableType ScalableType, scalableId string, override LevelOverridePostBody) error {
+	target := path.Join("/api/v1/services/", string(scalableType), url.PathEscape(scalableId), "override")
+	return sendOverride(ctx, client, target, fmt.Sprintf("scalable %s/%s", scalableType, scalableId), &override)
+}
+
+// ClearScalableOverride returns a scalable to its schedule.
+func (client *SssClient) ClearScalableOverride(ctx context.Context, scalableType ScalableType, scalableId string) error {
+	target := path.Join("/api/v1/services/", string(scalableType), url.PathEscape(scalableId), "override")
+	return sendOverride(ctx, client, target, fmt.Sprintf("scalable %s/%s", scalableType, scalableId), nil)
+}
+
+// SetGroupLevel overrides the schedule of every scalable in a group and
+// switches them to a level immediately.
+func (client *SssClient) SetGroupLevel(ctx context.Context, group string, override LevelOverridePostBody) error {
+	target := path.Join("/api/v1/groups/", url.PathEscape(group), "override")
+	return sendOverride(ctx, client, target, fmt.Sprintf("group %s", group), &override)
+}
+
+// ClearGroupOverride returns every scalable in a group to its schedule.
+func (client *SssClient) ClearGroupOverride(ctx context.Context, group string) error {
+	target := path.Join("/api/v1/groups/", url.PathEscape(group), "override")
+	return sendOverride(ctx, client, target, fmt.Sprintf("group %s", group), nil)
+}
+
+// sendOverride POSTs the override to target, or DELETEs the override at
+// target when override is nil.
+func sendOverride(ctx context.Context, client *SssClient, target string, description string, override *LevelOverridePostBody) error {
+	method := "DELETE"
+	var body any
+	if override != nil {
+		method = "POST"
+		body = override
+	}
+	response, err := client.do(ctx, method, target, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	operation := "override"
+	if override == nil {
+		operation = "clear override of"
+	}
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return fmt.Errorf("failed to %s %s: %s: %w", operation, description, response.Status, ErrNotFound)
+	}
+	return fmt.Errorf("failed to %s %s: %s", operation, description, response.Status)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// ApplyStatusFailed is the LastApplyStatus of a scalable SSS failed to scale.
+const ApplyStatusFailed = "failed"
+
+// GetScalableStatus returns the level and capacity SSS has currently applied
+// to a scalable.
+func (client *SssClient) GetScalableStatus(ctx context.Context, scalableType ScalableType, scalableId string) (*ScalableStatusResponse, error) {
+	response, err := client.do(ctx, "GET", path.Join("/api/v1/services/", string(scalableType), url.PathEscape(scalableId), "status"), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("failed to get status of scalable %s/%s: %s: %w", string(scalableType), scalableId, response.Status, ErrNotFound)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get status of scalable %s/%s: %s", string(scalableType), scalableId, response.Status)
+	}
+	var status ScalableStatusResponse
+	err = json.NewDecoder(response.Body).Decode(&status)
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// ErrTransportUnresolved is returned for requests of a client whose TLS or
+// proxy settings are not known yet.
+var ErrTransportUnresolved = errors.New("the TLS and proxy settings of the provider are not known yet")
+
+// TLSSettings configure how the client verifies SSS and authenticates to it.
+type TLSSettings struct {
+	// CACertPEM holds PEM encoded certificates of CAs to trust in addition to
+	// the system CAs.
+	CACertPEM string
+	// ClientCertPEM and ClientKeyPEM hold the PEM encoded certificate and key
+	// the client authenticates with, for mutual TLS.
+	ClientCertPEM      string
+	ClientKeyPEM       string
+	InsecureSkipVerify bool
+}
+
+// Config returns the TLS configuration of the settings, or nil when the
+// settings are all empty and the defaults apply.
+func (s TLSSettings) Config() (*tls.Config, error) {
+	if s == (TLSSettings{}) {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: s.InsecureSkipVerify,
+	}
+	if s.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(s.CACertPEM)) {
+			return nil, errors.New("the CA certificate holds no PEM encoded certificates")
+		}
+		config.RootCAs = pool
+	}
+	if s.ClientCertPEM != "" || s.ClientKeyPEM != "" {
+		if s.ClientCertPEM == "" || s.ClientKeyPEM == "" {
+			return nil, errors.New("the client certificate and key must be given together")
+		}
+		certificate, err := tls.X509KeyPair([]byte(s.ClientCertPEM), []byte(s.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+// ReadCACertFile reads the PEM encoded CA certificates of a file.
+func ReadCACertFile(filename string) (string, error) {
+	pem, err := os.ReadFile(filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read CA certificate file: %w", err)
+	}
+	return string(pem), nil
+}
+
+// ParseProxyURL parses the URL of an HTTP, HTTPS or SOCKS5 proxy.
+func ParseProxyURL(proxyURL string) (*url.URL, error) {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch parsed.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid proxy URL %q: the scheme must be http, https or socks5", proxyURL)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q: missing host", proxyURL)
+	}
+	return parsed, nil
+}
+
+// WithTLSConfig makes the client connect to SSS with config.
+func WithTLSConfig(config *tls.Config) SssClientOption {
+	return func(client *SssClient) {
+		client.tlsConfig = config
+	}
+}
+
+// WithProxy sends requests through the proxy at proxyURL instead of the one
+// configured by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment
+// variables.
+func WithProxy(proxyURL *url.URL) SssClientOption {
+	return func(client *SssClient) {
+		client.proxyURL = proxyURL
+	}
+}
+
+// WithUnresolvedTransport makes the client fail every request with
+// ErrTransportUnresolved, for when the TLS or proxy settings depend on values
+// that are only known during apply. Sending the requests with the default
+// transport would bypass the proxy or trust other CAs than configured.
+func WithUnresolvedTransport() SssClientOption {
+	return func(client *SssClient) {
+		client.unresolved = true
+	}
+}
+
+// TransportResolved reports whether the TLS and proxy settings of the client
+// are known, so that it can send requests.
+func (client *SssClient) TransportResolved() bool {
+	return !client.unresolved
+}
+
+// unresolvedTransport fails every request with ErrTransportUnresolved.
+type unresolvedTransport struct{}
+
+func (unresolvedTransport) RoundTrip(_ *http.Request) (*http.Response, error) {
+	return nil, ErrTransportUnresolved
+}
@@ -0,0 +1,51 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+)
+
+// CreateApiToken exchanges the client credentials for a short-lived token
+// limited to the requested scopes.
+func (client *SssClient) CreateApiToken(ctx context.Context, token ApiTokenPostBody) (*ApiTokenResponse, error) {
+	response, err := client.do(ctx, "POST", "/api/v1/tokens", token)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to create API token: %s", response.Status)
+	}
+	var tokenResponse ApiTokenResponse
+	err = json.NewDecoder(response.Body).Decode(&tokenResponse)
+	if err != nil {
+		return nil, err
+	}
+	if tokenResponse.Token == "" {
+		return nil, fmt.Errorf("failed to create API token: SSS returned no token")
+	}
+	return &tokenResponse, nil
+}
+
+// RevokeApiToken revokes a token before it expires.
+func (client *SssClient) RevokeApiToken(ctx context.Context, tokenId string) error {
+	response, err := client.do(ctx, "DELETE", path.Join("/api/v1/tokens/", url.PathEscape(tokenId)), nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("failed to revoke API token %s: %s: %w", tokenId, response.Status, ErrNotFound)
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("failed to revoke API token %s: %s", tokenId, response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"terraform-provider-sss/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource                   = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure      = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithValidateConfig = &apiTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose          = &apiTokenEphemeralResource{}
+)
+
+// apiTokenPrivateKey is the private data key holding the token to revoke on close.
+const apiTokenPrivateKey = "api_token"
+
+// NewApiTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewApiTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &apiTokenEphemeralResource{}
+}
+
+// apiTokenEphemeralResource is the ephemeral resource implementation.
+type apiTokenEphemeralResource struct {
+	client *client.SssClient
+}
+
+// apiTokenEphemeralResourceModel maps the ephemeral resource schema data.
+type apiTokenEphemeralResourceModel struct {
+	Scopes    types.List   `tfsdk:"scopes"`
+	TTL       types.String `tfsdk:"ttl"`
+	Region    types.String `tfsdk:"region"`
+	TokenID   types.String `tfsdk:"token_id"`
+	Token     types.String `tfsdk:"token"`
+	ExpiresAt types.String `tfsdk:"expires_at"`
+}
+
+// apiTokenPrivateData is stored in the private data so the token can be
+// revoked on the endpoint that issued it.
+type apiTokenPrivateData struct {
+	ID     string `json:"id"`
+	Region string `json:"region"`
+}
+
+func (r *apiTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.SssClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Ephemeral Resource Configure Type", fmt.Sprintf("Expected *client.SssClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = c
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *apiTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *apiTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Exchanges the provider credentials for a short-lived SSS API token limited to a set of scopes. The token is never stored in plan or state, and is revoked when Terraform no longer needs it.",
+		Attributes: map[string]schema.Attribute{
+			"scopes": schema.ListAttribute{
+				MarkdownDescription: "The scopes granted to the token, e.g. `read` or `override`.",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"ttl": schema.StringAttribute{
+				MarkdownDescription: "How long the token is valid, as a Go duration such as `15m` or `1h`. Defaults to the SSS default token lifetime.",
+				Optional:            true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region whose SSS endpoint issues the token. E.g. eu-west-1. Defaults to the provider `host`.",
+				Optional:            true,
+			},
+			"token_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the token, used to revoke it.",
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The bearer token to authenticate SSS API requests with.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expires_at": schema.StringAttribute{
+				MarkdownDescription: "When the token expires, in RFC 3339 format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the scopes and TTL.
+func (r *apiTokenEphemeralResource) ValidateConfig(ctx context.Context, req ephemeral.ValidateConfigRequest, resp *ephemeral.ValidateConfigResponse) {
+	var config apiTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Scopes.IsUnknown() && len(config.Scopes.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("scopes"), "Missing Scopes", "At least one scope must be configured.")
+	}
+
+	if !config.TTL.IsNull() && !config.TTL.IsUnknown() {
+		if _, err := parseDurationSeconds("ttl", config.TTL.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid TTL", err.Error())
+		}
+	}
+}
+
+// Open creates the token.
+func (r *apiTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data apiTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var body client.ApiTokenPostBody
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &body.Scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !data.TTL.IsNull() {
+		ttl, err := parseDurationSeconds("ttl", data.TTL.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ttl"), "Invalid TTL", err.Error())
+			return
+		}
+		body.TtlSeconds = int64(ttl / time.Second)
+	}
+
+	// A dry run would yield an empty token that its consumers fail on later.
+	if r.client.DryRun() {
+		resp.Diagnostics.AddError("Failed to create API token", "The provider is configured with dry_run, which does not create API tokens. Open sss_api_token with a provider configuration without dry_run.")
+		return
+	}
+
+	token, err := r.client.ForRegion(data.Region.ValueString()).CreateApiToken(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create API token", err.Error())
+		return
+	}
+
+	data.TokenID = types.StringValue(token.ID)
+	data.Token = types.StringValue(token.Token)
+	data.ExpiresAt = types.StringValue(token.ExpiresAt.UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	private, err := json.Marshal(apiTokenPrivateData{ID: token.ID, Region: data.Region.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create API token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiTokenPrivateKey, private)...)
+}
+
+// Close revokes the token. A token that has already expired is ignored.
+func (r *apiTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	private, diags := req.Private.GetKey(ctx, apiTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || private == nil {
+		return
+	}
+
+	var token apiTokenPrivateData
+	if err := json.Unmarshal(private, &token); err != nil {
+		resp.Diagnostics.AddError("Failed to revoke API token", err.Error())
+		return
+	}
+
+	err := r.client.ForRegion(token.Region).RevokeApiToken(ctx, token.ID)
+	if err != nil && !errors.Is(err, client.ErrNotFound) {
+		resp.Diagnostics.AddError("Failed to revoke API token", err.Error())
+	}
+}
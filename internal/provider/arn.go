@@ -0,0 +1,123 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// awsPartitions lists the AWS partitions an ARN may belong to.
+var awsPartitions = []string{"aws", "aws-cn", "aws-us-gov", "aws-iso", "aws-iso-b", "aws-iso-e", "aws-iso-f", "aws-eusc"}
+
+// awsRegionPattern matches region names, whose prefix is the two letter area
+// code of most partitions but longer in others, such as eusc-de-east-1.
+var awsRegionPattern = regexp.MustCompile(`^[a-z]{2,4}(-[a-z]+)+-\d+$`)
+var awsAccountIDPattern = regexp.MustCompile(`^\d{12}$`)
+
+// awsArn holds the components of an Amazon Resource Name.
+type awsArn struct {
+	Partition string
+	Service   string
+	Region    string
+	AccountID string
+	Resource  string
+}
+
+// parseAwsArn splits an ARN of the given service into its components. Regional
+// services are expected, so the region and account ID must both be present.
+func parseAwsArn(arn string, service string) (awsArn, error) {
+	sections := strings.SplitN(arn, ":", 6)
+	if len(sections) != 6 || sections[0] != "arn" {
+		return awsArn{}, fmt.Errorf("%q is not an ARN, expected format \"arn:PARTITION:%s:REGION:ACCOUNT_ID:RESOURCE\"", arn, service)
+	}
+
+	parsed := awsArn{
+		Partition: sections[1],
+		Service:   sections[2],
+		Region:    sections[3],
+		AccountID: sections[4],
+		Resource:  sections[5],
+	}
+	if !slices.Contains(awsPartitions, parsed.Partition) {
+		return awsArn{}, fmt.Errorf("ARN %q has unknown partition %q, expected one of: %s", arn, parsed.Partition, strings.Join(awsPartitions, ", "))
+	}
+	if parsed.Service != service {
+		return awsArn{}, fmt.Errorf("ARN %q belongs to service %q, expected %q", arn, parsed.Service, service)
+	}
+	if !awsRegionPattern.MatchString(parsed.Region) {
+		return awsArn{}, fmt.Errorf("ARN %q has invalid region %q, expected a region such as eu-west-1", arn, parsed.Region)
+	}
+	if !awsAccountIDPattern.MatchString(parsed.AccountID) {
+		return awsArn{}, fmt.Errorf("ARN %q has invalid account ID %q, expected 12 digits", arn, parsed.AccountID)
+	}
+	if parsed.Resource == "" {
+		return awsArn{}, fmt.Errorf("ARN %q has an empty resource", arn)
+	}
+	return parsed, nil
+}
+
+// scalableArn is a scalable ID derived from an ARN, with the partition and
+// region of the ARN.
+type scalableArn struct {
+	ID        string `tfsdk:"id"`
+	Region    string `tfsdk:"region"`
+	Partition string `tfsdk:"partition"`
+}
+
+// parseScalableArn parses an ARN of the given service, and derives the
+// scalable ID from its resource with scalableID.
+func parseScalableArn(arn string, service string, scalableID func(arn string, resource string) (string, error)) (scalableArn, error) {
+	parsed, err := parseAwsArn(arn, service)
+	if err != nil {
+		return scalableArn{}, err
+	}
+	id, err := scalableID(arn, parsed.Resource)
+	if err != nil {
+		return scalableArn{}, err
+	}
+	return scalableArn{ID: id, Region: parsed.Region, Partition: parsed.Partition}, nil
+}
+
+// parseEcsServiceArn parses an ECS service ARN into the
+// "service/CLUSTER_NAME/SERVICE_NAME" form used by sss_ecs_scaling.
+func parseEcsServiceArn(arn string) (scalableArn, error) {
+	return parseScalableArn(arn, "ecs", func(arn string, resource string) (string, error) {
+		resourceType, resourceID, _ := strings.Cut(resource, "/")
+		if resourceType != "service" {
+			return "", fmt.Errorf("ARN %q is an ECS %q, expected a service", arn, resourceType)
+		}
+		cluster, service, found := strings.Cut(resourceID, "/")
+		if !found {
+			return "", fmt.Errorf("ARN %q uses the legacy ECS service ARN format without a cluster name, use the long ARN format \"service/CLUSTER_NAME/SERVICE_NAME\"", arn)
+		}
+		if cluster == "" || service == "" || strings.Contains(service, "/") {
+			return "", fmt.Errorf("ARN %q has malformed resource %q, expected \"service/CLUSTER_NAME/SERVICE_NAME\"", arn, resource)
+		}
+		return "service/" + cluster + "/" + service, nil
+	})
+}
+
+var dynamoTableNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,255}$`)
+
+// parseDynamoTableArn parses a DynamoDB table ARN into the "table/TABLE_NAME"
+// form used by sss_dynamo_table_scaling.
+func parseDynamoTableArn(arn string) (scalableArn, error) {
+	return parseScalableArn(arn, "dynamodb", func(arn string, resource string) (string, error) {
+		resourceType, resourceID, _ := strings.Cut(resource, "/")
+		if resourceType != "table" {
+			return "", fmt.Errorf("ARN %q is a DynamoDB %q, expected a table", arn, resourceType)
+		}
+		table, subresource, found := strings.Cut(resourceID, "/")
+		if found {
+			return "", fmt.Errorf("ARN %q refers to a table sub-resource %q, use the ARN of table %q instead", arn, subresource, table)
+		}
+		if !dynamoTableNamePattern.MatchString(table) {
+			return "", fmt.Errorf("ARN %q has invalid table name %q", arn, table)
+		}
+		return "table/" + table, nil
+	})
+}
@@ -0,0 +1,125 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &arnFunction{}
+
+// NewEcsServiceIDFromArnFunction is a helper function to simplify the provider implementation.
+func NewEcsServiceIDFromArnFunction() function.Function {
+	return &arnFunction{
+		name:        "ecs_service_id_from_arn",
+		summary:     "Derive an sss_ecs_scaling service ID from an ECS service ARN.",
+		description: "Converts an ECS service ARN such as \"arn:aws:ecs:eu-west-1:123456789012:service/CLUSTER_NAME/SERVICE_NAME\" into the \"service/CLUSTER_NAME/SERVICE_NAME\" service ID used by sss_ecs_scaling. Legacy service ARNs without a cluster name are rejected.",
+		arnName:     "ECS service",
+		parse:       parseEcsServiceArn,
+	}
+}
+
+// NewParseEcsServiceArnFunction is a helper function to simplify the provider implementation.
+func NewParseEcsServiceArnFunction() function.Function {
+	return &arnFunction{
+		name:        "parse_ecs_service_arn",
+		summary:     "Parse an ECS service ARN into an sss_ecs_scaling service ID, region and partition.",
+		description: "Parses an ECS service ARN as ecs_service_id_from_arn does, into an object with the id, region and partition attributes, e.g. the service_id and region of sss_ecs_scaling.",
+		arnName:     "ECS service",
+		parse:       parseEcsServiceArn,
+		object:      true,
+	}
+}
+
+// NewDynamoTableIDFromArnFunction is a helper function to simplify the provider implementation.
+func NewDynamoTableIDFromArnFunction() function.Function {
+	return &arnFunction{
+		name:        "dynamo_table_id_from_arn",
+		summary:     "Derive an sss_dynamo_table_scaling table name from a DynamoDB table ARN.",
+		description: "Converts a DynamoDB table ARN such as \"arn:aws:dynamodb:eu-west-1:123456789012:table/TABLE_NAME\" into the \"table/TABLE_NAME\" form used by sss_dynamo_table_scaling. Index and stream ARNs are rejected.",
+		arnName:     "DynamoDB table",
+		parse:       parseDynamoTableArn,
+	}
+}
+
+// NewParseDynamoTableArnFunction is a helper function to simplify the provider implementation.
+func NewParseDynamoTableArnFunction() function.Function {
+	return &arnFunction{
+		name:        "parse_dynamo_table_arn",
+		summary:     "Parse a DynamoDB table ARN into an sss_dynamo_table_scaling table name, region and partition.",
+		description: "Parses a DynamoDB table ARN as dynamo_table_id_from_arn does, into an object with the id, region and partition attributes, e.g. the table_name and region of sss_dynamo_table_scaling.",
+		arnName:     "DynamoDB table",
+		parse:       parseDynamoTableArn,
+		object:      true,
+	}
+}
+
+// arnFunction is the implementation of the functions that derive scalable IDs
+// from ARNs. It returns the ID, or an object with the ID, region and
+// partition when object is set.
+type arnFunction struct {
+	name        string
+	summary     string
+	description string
+	arnName     string
+	parse       func(arn string) (scalableArn, error)
+	object      bool
+}
+
+// Metadata returns the function name.
+func (f *arnFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = f.name
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *arnFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	var result function.Return = function.StringReturn{}
+	if f.object {
+		result = function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"id":        types.StringType,
+				"region":    types.StringType,
+				"partition": types.StringType,
+			},
+		}
+	}
+	resp.Definition = function.Definition{
+		Summary:     f.summary,
+		Description: f.description,
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "arn",
+				Description: "The ARN of the " + f.arnName + ".",
+			},
+		},
+		Return: result,
+	}
+}
+
+// Run parses the ARN and returns the scalable ID, or all its components.
+func (f *arnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var arn string
+
+	resp.Error = req.Arguments.Get(ctx, &arn)
+	if resp.Error != nil {
+		return
+	}
+
+	parsed, err := f.parse(arn)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	if f.object {
+		resp.Error = resp.Result.Set(ctx, parsed)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, parsed.ID)
+}
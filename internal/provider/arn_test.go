@@ -0,0 +1,108 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseEcsServiceArn(t *testing.T) {
+	tests := []struct {
+		arn     string
+		want    scalableArn
+		wantErr string
+	}{
+		{arn: "arn:aws:ecs:eu-west-1:123456789012:service/cluster/app", want: scalableArn{ID: "service/cluster/app", Region: "eu-west-1", Partition: "aws"}},
+		{arn: "arn:aws-eusc:ecs:eusc-de-east-1:123456789012:service/cluster/app", want: scalableArn{ID: "service/cluster/app", Region: "eusc-de-east-1", Partition: "aws-eusc"}},
+		{arn: "arn:aws-us-gov:ecs:us-gov-west-1:123456789012:service/cluster/app", want: scalableArn{ID: "service/cluster/app", Region: "us-gov-west-1", Partition: "aws-us-gov"}},
+		{arn: "service/cluster/app", wantErr: "is not an ARN"},
+		{arn: "arn:aws-mars:ecs:eu-west-1:123456789012:service/cluster/app", wantErr: `unknown partition "aws-mars"`},
+		{arn: "arn:aws:dynamodb:eu-west-1:123456789012:service/cluster/app", wantErr: `belongs to service "dynamodb"`},
+		{arn: "arn:aws:ecs:europe:123456789012:service/cluster/app", wantErr: `invalid region "europe"`},
+		{arn: "arn:aws:ecs:eu-west-1:1234:service/cluster/app", wantErr: `invalid account ID "1234"`},
+		{arn: "arn:aws:ecs:eu-west-1:123456789012:", wantErr: "empty resource"},
+		{arn: "arn:aws:ecs:eu-west-1:123456789012:cluster/cluster", wantErr: `is an ECS "cluster"`},
+		{arn: "arn:aws:ecs:eu-west-1:123456789012:service/app", wantErr: "legacy ECS service ARN format"},
+		{arn: "arn:aws:ecs:eu-west-1:123456789012:service/cluster/app/extra", wantErr: "malformed resource"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arn, func(t *testing.T) {
+			testParseScalableArn(t, parseEcsServiceArn, tt.arn, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestParseDynamoTableArn(t *testing.T) {
+	tests := []struct {
+		arn     string
+		want    scalableArn
+		wantErr string
+	}{
+		{arn: "arn:aws:dynamodb:eu-west-1:123456789012:table/entries", want: scalableArn{ID: "table/entries", Region: "eu-west-1", Partition: "aws"}},
+		{arn: "arn:aws-cn:dynamodb:cn-north-1:123456789012:table/entries.v2", want: scalableArn{ID: "table/entries.v2", Region: "cn-north-1", Partition: "aws-cn"}},
+		{arn: "arn:aws:dynamodb:eu-west-1:123456789012:table/entries/index/by-date", wantErr: `sub-resource "index/by-date"`},
+		{arn: "arn:aws:dynamodb:eu-west-1:123456789012:global-table/entries", wantErr: `is a DynamoDB "global-table"`},
+		{arn: "arn:aws:dynamodb:eu-west-1:123456789012:table/ab", wantErr: `invalid table name "ab"`},
+		{arn: "arn:aws:dynamodb:eu-west-1:123456789012:table/entries!", wantErr: "invalid table name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arn, func(t *testing.T) {
+			testParseScalableArn(t, parseDynamoTableArn, tt.arn, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func testParseScalableArn(t *testing.T, parse func(string) (scalableArn, error), arn string, want scalableArn, wantErr string) {
+	t.Helper()
+	got, err := parse(arn)
+	if wantErr != "" {
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("parse(%q) = %v, want error containing %q", arn, err, wantErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("parse(%q) returned error: %v", arn, err)
+	}
+	if got != want {
+		t.Fatalf("parse(%q) = %+v, want %+v", arn, got, want)
+	}
+}
+
+func TestArnFunctionRun(t *testing.T) {
+	const arn = "arn:aws-eusc:ecs:eusc-de-east-1:123456789012:service/cluster/app"
+	tests := []struct {
+		name string
+		f    function.Function
+		want string
+	}{
+		{name: "id", f: NewEcsServiceIDFromArnFunction(), want: `"service/cluster/app"`},
+		{name: "object", f: NewParseEcsServiceArnFunction(), want: `{"id":"service/cluster/app","partition":"aws-eusc","region":"eusc-de-east-1"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var definition function.DefinitionResponse
+			tt.f.Definition(ctx, function.DefinitionRequest{}, &definition)
+			result, funcErr := definition.Definition.Return.NewResultData(ctx)
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+			resp := function.RunResponse{Result: result}
+			tt.f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(arn)})}, &resp)
+			if resp.Error != nil {
+				t.Fatalf("Run() returned error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().String(); got != tt.want {
+				t.Fatalf("Run() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
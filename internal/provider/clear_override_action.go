@@ -0,0 +1,97 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &clearOverrideAction{}
+	_ action.ActionWithConfigure      = &clearOverrideAction{}
+	_ action.ActionWithValidateConfig = &clearOverrideAction{}
+)
+
+// NewClearOverrideAction is a helper function to simplify the provider implementation.
+func NewClearOverrideAction() action.Action {
+	return &clearOverrideAction{}
+}
+
+// clearOverrideAction is the action implementation.
+type clearOverrideAction struct {
+	client *client.SssClient
+}
+
+func (a *clearOverrideAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.SssClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Action Configure Type", fmt.Sprintf("Expected *client.SssClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	a.client = c
+}
+
+// Metadata returns the action type name.
+func (a *clearOverrideAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_clear_override"
+}
+
+// Schema defines the schema for the action.
+func (a *clearOverrideAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Clears a level override set with `sss_set_level`, returning a scalable, or every scalable in a group, to its schedule.",
+		Attributes:          levelOverrideTargetAttributes(),
+	}
+}
+
+// ValidateConfig validates the target.
+func (a *clearOverrideAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var config levelOverrideTargetModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(config.validate()...)
+}
+
+// Invoke clears the override in SSS.
+func (a *clearOverrideAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config levelOverrideTargetModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Clearing the override of %s", config.description())})
+
+	c := a.client.ForRegion(config.Region.ValueString())
+	var err error
+	if !config.Group.IsNull() {
+		err = c.ClearGroupOverride(ctx, config.Group.ValueString())
+	} else {
+		err = c.ClearScalableOverride(ctx, client.ScalableType(config.ScalableType.ValueString()), config.ScalableID.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to clear override", err.Error())
+		return
+	}
+
+	// In a dry run the request is only logged, so the override is still set.
+	if a.client.DryRun() {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Dry run, logged clearing the override of %s without sending it to SSS", config.description())})
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Cleared the override of %s", config.description())})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &dynamoTableScalingListResource{}
+	_ list.ListResourceWithConfigure = &dynamoTableScalingListResource{}
+)
+
+// NewDynamoTableScalingListResource is a helper function to simplify the provider implementation.
+func NewDynamoTableScalingListResource() list.ListResource {
+	return &dynamoTableScalingListResource{}
+}
+
+// dynamoTableScalingListResource is the list resource implementation.
+type dynamoTableScalingListResource struct {
+	client      *client.SssClient
+	defaultTags map[string]string
+}
+
+func (r *dynamoTableScalingListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected List Resource Configure Type", fmt.Sprintf("Expected *provider.resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *dynamoTableScalingListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dynamo_table_scaling"
+}
+
+// ListResourceConfigSchema defines the schema for the list configuration.
+func (r *dynamoTableScalingListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = scalableListConfigSchema("Lists DynamoDB table scaling registrations.")
+}
+
+// List streams the registrations found in SSS.
+func (r *dynamoTableScalingListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	scalableListing[client.DynamoTableResponse]{
+		scalableType: client.ScalableTypeDynamoDB,
+		name:         "dynamo table scaling",
+		list:         (*client.SssClient).ListDynamoTables,
+		id:           func(m *client.DynamoTableResponse) string { return m.TableName },
+		region:       func(m *client.DynamoTableResponse) string { return m.Region },
+		tags:         func(m *client.DynamoTableResponse) map[string]string { return m.Tags },
+		toModel:      func(m *client.DynamoTableResponse) any { return ToDynamoTableResourceModel(m, r.defaultTags) },
+	}.stream(ctx, r.client, req, stream)
+}
@@ -5,21 +5,30 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"terraform-provider-sss/internal/client"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &dynamoTableScalingResource{}
-	_ resource.ResourceWithConfigure   = &dynamoTableScalingResource{}
-	_ resource.ResourceWithImportState = &dynamoTableScalingResource{}
+	_ resource.Resource                 = &dynamoTableScalingResource{}
+	_ resource.ResourceWithConfigure    = &dynamoTableScalingResource{}
+	_ resource.ResourceWithImportState  = &dynamoTableScalingResource{}
+	_ resource.ResourceWithIdentity     = &dynamoTableScalingResource{}
+	_ resource.ResourceWithModifyPlan   = &dynamoTableScalingResource{}
+	_ resource.ResourceWithUpgradeState = &dynamoTableScalingResource{}
+	_ resource.ResourceWithMoveState    = &dynamoTableScalingResource{}
 )
 
 type dynamoTableCapacityValue struct {
@@ -30,27 +39,43 @@ type dynamoTableCapacityValue struct {
 }
 
 type dynamoTableCapacityModel struct {
-	Min     dynamoTableCapacityValue `tfsdk:"low"`
+	Low     dynamoTableCapacityValue `tfsdk:"low"`
 	Medium  dynamoTableCapacityValue `tfsdk:"medium"`
 	High    dynamoTableCapacityValue `tfsdk:"high"`
 	Extreme dynamoTableCapacityValue `tfsdk:"extreme"`
 }
 
 type dynamoTableScalingResourceModel struct {
-	TableName   types.String             `tfsdk:"table_name"`
-	Region      types.String             `tfsdk:"region"`
-	Capacity    dynamoTableCapacityModel `tfsdk:"capacity"`
-	LastUpdated types.String             `tfsdk:"last_updated"`
+	TableName         types.String              `tfsdk:"table_name"`
+	Region            types.String              `tfsdk:"region"`
+	Capacity          dynamoTableCapacityModel  `tfsdk:"capacity"`
+	LastUpdated       types.String              `tfsdk:"last_updated"`
+	WaitForApply      *waitForApplyModel        `tfsdk:"wait_for_apply"`
+	EffectiveCapacity *dynamoTableCapacityValue `tfsdk:"effective_capacity"`
+	Timeouts          timeouts.Value            `tfsdk:"timeouts"`
+	ScalableID        types.String              `tfsdk:"scalable_id"`
+	Endpoint          types.String              `tfsdk:"endpoint"`
+	AllowScaleDown    types.Bool                `tfsdk:"allow_scale_down_during_event"`
+	suspendModel
+	ownershipModel
+	scalableStatusModel
 }
 
 func (m *dynamoTableScalingResourceModel) ToClientModel() (string, client.DynamoTablePostBody) {
+	suspended, suspendedUntil := m.suspendModel.toClientModel()
+	tags, owner, contact := m.ownershipModel.toClientModel()
 	return m.TableName.ValueString(), client.DynamoTablePostBody{
-		Region: m.Region.ValueString(),
+		Region:         m.Region.ValueString(),
+		Suspended:      suspended,
+		SuspendedUntil: suspendedUntil,
+		Tags:           tags,
+		Owner:          owner,
+		Contact:        contact,
 		LowCapacity: client.DynamoTableCapacity{
-			MinWriteCapacity: m.Capacity.Min.MinWriteCapacity.ValueInt64(),
-			MinReadCapacity:  m.Capacity.Min.MinReadCapacity.ValueInt64(),
-			MaxWriteCapacity: m.Capacity.Min.MaxWriteCapacity.ValueInt64(),
-			MaxReadCapacity:  m.Capacity.Min.MaxReadCapacity.ValueInt64(),
+			MinWriteCapacity: m.Capacity.Low.MinWriteCapacity.ValueInt64(),
+			MinReadCapacity:  m.Capacity.Low.MinReadCapacity.ValueInt64(),
+			MaxWriteCapacity: m.Capacity.Low.MaxWriteCapacity.ValueInt64(),
+			MaxReadCapacity:  m.Capacity.Low.MaxReadCapacity.ValueInt64(),
 		},
 		MediumCapacity: client.DynamoTableCapacity{
 			MinWriteCapacity: m.Capacity.Medium.MinWriteCapacity.ValueInt64(),
@@ -73,12 +98,42 @@ func (m *dynamoTableScalingResourceModel) ToClientModel() (string, client.Dynamo
 	}
 }
 
-func ToDynamoTableResourceModel(m *client.DynamoTableResponse) dynamoTableScalingResourceModel {
+// isApplied reports whether SSS has applied the capacity of the current level.
+func (m *dynamoTableScalingResourceModel) isApplied(status *client.ScalableStatusResponse) bool {
+	_, capacities := m.ToClientModel()
+	capacity, ok := map[string]client.DynamoTableCapacity{
+		"low":     capacities.LowCapacity,
+		"medium":  capacities.MediumCapacity,
+		"high":    capacities.HighCapacity,
+		"extreme": capacities.ExtremeCapacity,
+	}[status.CurrentLevel]
+	return ok && status.EffectiveCapacity != nil && *status.EffectiveCapacity == capacity
+}
+
+// setStatus populates the computed status attributes.
+func (m *dynamoTableScalingResourceModel) setStatus(status *client.ScalableStatusResponse) {
+	m.scalableStatusModel = newScalableStatusModel(status)
+	m.EffectiveCapacity = nil
+	if status != nil && status.EffectiveCapacity != nil {
+		m.EffectiveCapacity = &dynamoTableCapacityValue{
+			MinWriteCapacity: types.Int64Value(status.EffectiveCapacity.MinWriteCapacity),
+			MinReadCapacity:  types.Int64Value(status.EffectiveCapacity.MinReadCapacity),
+			MaxWriteCapacity: types.Int64Value(status.EffectiveCapacity.MaxWriteCapacity),
+			MaxReadCapacity:  types.Int64Value(status.EffectiveCapacity.MaxReadCapacity),
+		}
+	}
+}
+
+// ToDynamoTableResourceModel converts an SSS registration to the resource model. Tags
+// inherited from defaultTags are left out of tags, and only kept there while
+// defaultTags is nil because the default tags are not known.
+func ToDynamoTableResourceModel(m *client.DynamoTableResponse, defaultTags map[string]string) dynamoTableScalingResourceModel {
 	return dynamoTableScalingResourceModel{
-		TableName: types.StringValue(m.TableName),
-		Region:    types.StringValue(m.Region),
+		ScalableID: types.StringValue(m.TableName),
+		TableName:  types.StringValue(m.TableName),
+		Region:     types.StringValue(m.Region),
 		Capacity: dynamoTableCapacityModel{
-			Min: dynamoTableCapacityValue{
+			Low: dynamoTableCapacityValue{
 				MinWriteCapacity: types.Int64Value(m.LowCapacity.MinWriteCapacity),
 				MinReadCapacity:  types.Int64Value(m.LowCapacity.MinReadCapacity),
 				MaxWriteCapacity: types.Int64Value(m.LowCapacity.MaxWriteCapacity),
@@ -103,6 +158,9 @@ func ToDynamoTableResourceModel(m *client.DynamoTableResponse) dynamoTableScalin
 				MaxReadCapacity:  types.Int64Value(m.ExtremeCapacity.MaxReadCapacity),
 			},
 		},
+		Timeouts:       nullScalableTimeouts(),
+		suspendModel:   newSuspendModel(m.Suspended, m.SuspendedUntil, suspendModel{}),
+		ownershipModel: newOwnershipModel(m.Tags, m.Owner, m.Contact, defaultTags, types.MapNull(types.StringType)),
 	}
 }
 
@@ -117,28 +175,35 @@ func (r *dynamoTableScalingResource) Configure(_ context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.SssClient)
+	data, ok := req.ProviderData.(*resourceData)
 
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *client.SssClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *provider.resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
 
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+	r.guardrails = data.guardrails
 }
 
 // dynamoTableScalingResource is the resource implementation.
 type dynamoTableScalingResource struct {
-	client *client.SssClient
+	client      *client.SssClient
+	defaultTags map[string]string
+	guardrails  guardrailsModel
 }
 
 // Metadata returns the resource type name.
 func (r *dynamoTableScalingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_dynamo_table_scaling"
+	// A region change that stays on the same SSS host is applied in place,
+	// and changes the region of the identity.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 // Schema defines the schema for the resource.
-func (r *dynamoTableScalingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *dynamoTableScalingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	capacitySchema := schema.SingleNestedAttribute{
 		Description: "The capacity to use during the different schedules.",
 		Required:    true,
@@ -151,19 +216,37 @@ func (r *dynamoTableScalingResource) Schema(_ context.Context, _ resource.Schema
 	}
 
 	resp.Schema = schema.Schema{
+		Version:     scalableSchemaVersion,
 		Description: "Manages scaling for DynamoDB Tables.",
 		Attributes: map[string]schema.Attribute{
 			"table_name": schema.StringAttribute{
-				Description: "The arn of the table",
+				Description: "The table ID. Should be in format table/TABLE_NAME",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"region": schema.StringAttribute{
 				Description: "The AWS region the service is located in. E.g. eu-west-1",
 				Required:    true,
 			},
+			"scalable_id":                   scalableIDAttribute(),
+			"endpoint":                      endpointAttribute(),
+			"allow_scale_down_during_event": allowScaleDownAttribute(),
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"wait_for_apply": waitForApplySchema(),
+			"effective_capacity": schema.SingleNestedAttribute{
+				Description: "The capacity SSS currently applies for current_level.",
+				Computed:    true,
+				Attributes: map[string]schema.Attribute{
+					"min_write": schema.Int64Attribute{Computed: true},
+					"max_write": schema.Int64Attribute{Computed: true},
+					"min_read":  schema.Int64Attribute{Computed: true},
+					"max_read":  schema.Int64Attribute{Computed: true},
+				},
+			},
 			"capacity": schema.SingleNestedAttribute{
 				Description: "The minimum number of tasks to have during different schedules.",
 				Required:    true,
@@ -175,7 +258,13 @@ func (r *dynamoTableScalingResource) Schema(_ context.Context, _ resource.Schema
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": scalableTimeoutsBlock(ctx),
+		},
 	}
+	maps.Copy(resp.Schema.Attributes, suspendAttributes())
+	maps.Copy(resp.Schema.Attributes, ownershipAttributes())
+	maps.Copy(resp.Schema.Attributes, scalableStatusAttributes())
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -186,22 +275,48 @@ func (r *dynamoTableScalingResource) Create(ctx context.Context, req resource.Cr
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeDynamoDB, plan.TableName)
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
+	plan.resolveTagsAll(r.defaultTags)
 	tableName, capacities := plan.ToClientModel()
 
-	err := r.client.CreateDynamoTable(tableName, capacities)
+	err := scalableClient(r.client, plan.Region, plan.Endpoint).CreateDynamoTable(ctx, tableName, capacities)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create dynamo table scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to create dynamo table scaling", clientErrorDetail(err, "create"))
 		return
 	}
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	c := scalableClient(r.client, plan.Region, plan.Endpoint)
+	var waitErr error
+	if !plan.Suspended.ValueBool() {
+		waitErr = plan.WaitForApply.wait(ctx, c, client.ScalableTypeDynamoDB, plan.TableName.ValueString(), plan.isApplied)
+	}
+	status, diags := getScalableStatus(ctx, c, client.ScalableTypeDynamoDB, plan.TableName.ValueString())
+	resp.Diagnostics.Append(diags...)
+	plan.setStatus(status)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setSuspendedUntilPrivate(ctx, resp.Private, plan.suspendModel)...)
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeDynamoDB, plan.TableName, plan.Region, plan.Endpoint)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Failed to apply dynamo table scaling", waitErr.Error())
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -212,24 +327,51 @@ func (r *dynamoTableScalingResource) Read(ctx context.Context, req resource.Read
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeDynamoDB, state.TableName)
 
-	response, err := r.client.GetDynamoTable(state.TableName.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	response, err := scalableClient(r.client, state.Region, state.Endpoint).GetDynamoTable(ctx, state.TableName.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		// The registration was removed outside Terraform, or never created
+		// because the provider ran in dry run mode.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read Dynamo DB table scaling", "Could not read scaling for table "+state.TableName.ValueString()+": "+err.Error())
+		resp.Diagnostics.AddError("Failed to read Dynamo DB table scaling", "Could not read scaling for table "+state.TableName.ValueString()+": "+clientErrorDetail(err, "read"))
 		return
 	}
 
-	newState := ToDynamoTableResourceModel(response)
+	newState := ToDynamoTableResourceModel(response, r.defaultTags)
 
 	if !state.LastUpdated.IsNull() {
 		newState.LastUpdated = state.LastUpdated
 	}
+	newState.WaitForApply = state.WaitForApply
+	newState.Timeouts = state.Timeouts
+	newState.Endpoint = state.Endpoint
+	newState.AllowScaleDown = state.AllowScaleDown
+	newState.suspendModel = newSuspendModel(response.Suspended, response.SuspendedUntil, state.suspendModel)
+	newState.ownershipModel = newOwnershipModel(response.Tags, response.Owner, response.Contact, r.defaultTags, state.Tags)
+
+	status, diags := getScalableStatus(ctx, scalableClient(r.client, state.Region, state.Endpoint), client.ScalableTypeDynamoDB, newState.TableName.ValueString())
+	resp.Diagnostics.Append(diags...)
+	newState.setStatus(status)
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeDynamoDB, newState.TableName, newState.Region, newState.Endpoint)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -240,20 +382,92 @@ func (r *dynamoTableScalingResource) Update(ctx context.Context, req resource.Up
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeDynamoDB, plan.TableName)
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	plan.resolveTagsAll(r.defaultTags)
 	tableName, capacities := plan.ToClientModel()
-	err := r.client.UpdateDynamoTable(tableName, capacities)
+	err := scalableClient(r.client, plan.Region, plan.Endpoint).UpdateDynamoTable(ctx, tableName, capacities)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update dynamo table scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to update dynamo table scaling", clientErrorDetail(err, "update"))
 		return
 	}
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	c := scalableClient(r.client, plan.Region, plan.Endpoint)
+	var waitErr error
+	if !plan.Suspended.ValueBool() {
+		waitErr = plan.WaitForApply.wait(ctx, c, client.ScalableTypeDynamoDB, plan.TableName.ValueString(), plan.isApplied)
+	}
+	status, diags := getScalableStatus(ctx, c, client.ScalableTypeDynamoDB, plan.TableName.ValueString())
+	resp.Diagnostics.Append(diags...)
+	plan.setStatus(status)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setSuspendedUntilPrivate(ctx, resp.Private, plan.suspendModel)...)
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeDynamoDB, plan.TableName, plan.Region, plan.Endpoint)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Failed to apply dynamo table scaling", waitErr.Error())
+	}
+}
+
+// ModifyPlan plans scalable_id, endpoint and tags_all, forces replacement
+// when a region change moves the scalable to another SSS endpoint, checks
+// suspended_until, enforces the provider guardrails, and checks that an
+// update does not lower the minimum capacity of the level SSS currently
+// applies.
+func (r *dynamoTableScalingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanScalableID(ctx, path.Root("table_name"), req, resp)
+	modifyPlanEndpoint(ctx, req, resp)
+	planRegionReplacement(ctx, r.client, req, resp)
+	modifyPlanSuspendedUntil(ctx, req, resp)
+	modifyPlanTagsAll(ctx, r.defaultTags, req, resp)
+	r.guardrails.modifyPlan(ctx, []guardedCapacity{
+		{path: dynamoCapacityPath("min_write"), limit: r.guardrails.MaxDynamoWriteCapacity, limitName: "max_dynamo_write_capacity", minimum: true},
+		{path: dynamoCapacityPath("max_write"), limit: r.guardrails.MaxDynamoWriteCapacity, limitName: "max_dynamo_write_capacity"},
+		{path: dynamoCapacityPath("min_read"), limit: r.guardrails.MaxDynamoReadCapacity, limitName: "max_dynamo_read_capacity", minimum: true},
+		{path: dynamoCapacityPath("max_read"), limit: r.guardrails.MaxDynamoReadCapacity, limitName: "max_dynamo_read_capacity"},
+	}, req, resp)
+	modifyPlanScaleDown(ctx, r.client, client.ScalableTypeDynamoDB, path.Root("table_name"), func(level string) []path.Path {
+		return []path.Path{dynamoCapacityPath("min_write")(level), dynamoCapacityPath("min_read")(level)}
+	}, req, resp)
+}
+
+// dynamoCapacityPath returns the path of a capacity attribute at a level.
+func dynamoCapacityPath(attribute string) func(level string) path.Path {
+	return func(level string) path.Path {
+		return path.Root("capacity").AtName(level).AtName(attribute)
+	}
+}
+
+// UpgradeState upgrades state written by earlier schema versions.
+func (r *dynamoTableScalingResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return scalableStateUpgraders("table_name", dynamoTableIDFromV0)
+}
+
+// MoveState moves state from restapi_object and renamed SSS resource types.
+func (r *dynamoTableScalingResource) MoveState(_ context.Context) []resource.StateMover {
+	return scalableMove[client.DynamoTableResponse]{
+		scalableType: client.ScalableTypeDynamoDB,
+		idAttribute:  "table_name",
+		idField:      "tableName",
+		id:           func(m *client.DynamoTableResponse) string { return m.TableName },
+		region:       func(m *client.DynamoTableResponse) string { return m.Region },
+		toModel:      func(m *client.DynamoTableResponse) any { return ToDynamoTableResourceModel(m, nil) },
+	}.movers()
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -264,13 +478,28 @@ func (r *dynamoTableScalingResource) Delete(ctx context.Context, req resource.De
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := r.client.DeleteDynamoTable(state.TableName.ValueString())
+	ctx = withScalableLogFields(ctx, client.ScalableTypeDynamoDB, state.TableName)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := scalableClient(r.client, state.Region, state.Endpoint).DeleteDynamoTable(ctx, state.TableName.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to dynamodb table scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to dynamodb table scaling", clientErrorDetail(err, "delete"))
 		return
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *dynamoTableScalingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scalableIdentitySchema()
+}
+
 func (r *dynamoTableScalingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("table_name"), req, resp)
+	importScalableState(ctx, client.ScalableTypeDynamoDB, path.Root("table_name"), req, resp)
 }
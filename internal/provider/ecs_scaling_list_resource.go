@@ -0,0 +1,67 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &ecsScalingListResource{}
+	_ list.ListResourceWithConfigure = &ecsScalingListResource{}
+)
+
+// NewEcsScalingListResource is a helper function to simplify the provider implementation.
+func NewEcsScalingListResource() list.ListResource {
+	return &ecsScalingListResource{}
+}
+
+// ecsScalingListResource is the list resource implementation.
+type ecsScalingListResource struct {
+	client      *client.SssClient
+	defaultTags map[string]string
+}
+
+func (r *ecsScalingListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected List Resource Configure Type", fmt.Sprintf("Expected *provider.resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *ecsScalingListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ecs_scaling"
+}
+
+// ListResourceConfigSchema defines the schema for the list configuration.
+func (r *ecsScalingListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = scalableListConfigSchema("Lists ECS service scaling registrations.")
+}
+
+// List streams the registrations found in SSS.
+func (r *ecsScalingListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	scalableListing[client.EcsServiceResponse]{
+		scalableType: client.ScalableTypeECS,
+		name:         "ECS service scaling",
+		list:         (*client.SssClient).ListEcsServices,
+		id:           func(m *client.EcsServiceResponse) string { return m.Name },
+		region:       func(m *client.EcsServiceResponse) string { return m.Region },
+		tags:         func(m *client.EcsServiceResponse) map[string]string { return m.Tags },
+		toModel:      func(m *client.EcsServiceResponse) any { return ToECSResourceModel(m, r.defaultTags) },
+	}.stream(ctx, r.client, req, stream)
+}
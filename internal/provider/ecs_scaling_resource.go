@@ -5,57 +5,110 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"terraform-provider-sss/internal/client"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &ecsScalingResource{}
-	_ resource.ResourceWithConfigure   = &ecsScalingResource{}
-	_ resource.ResourceWithImportState = &ecsScalingResource{}
+	_ resource.Resource                 = &ecsScalingResource{}
+	_ resource.ResourceWithConfigure    = &ecsScalingResource{}
+	_ resource.ResourceWithImportState  = &ecsScalingResource{}
+	_ resource.ResourceWithIdentity     = &ecsScalingResource{}
+	_ resource.ResourceWithModifyPlan   = &ecsScalingResource{}
+	_ resource.ResourceWithUpgradeState = &ecsScalingResource{}
+	_ resource.ResourceWithMoveState    = &ecsScalingResource{}
 )
 
 type ecsScalingResourceModel struct {
-	ServiceID   types.String             `tfsdk:"service_id"`
-	Region      types.String             `tfsdk:"region"`
-	MinTasks    *ecsScalingCapacityModel `tfsdk:"min_tasks"`
-	LastUpdated types.String             `tfsdk:"last_updated"`
+	ServiceID      types.String             `tfsdk:"service_id"`
+	Region         types.String             `tfsdk:"region"`
+	MinTasks       *ecsScalingCapacityModel `tfsdk:"min_tasks"`
+	LastUpdated    types.String             `tfsdk:"last_updated"`
+	WaitForApply   *waitForApplyModel       `tfsdk:"wait_for_apply"`
+	EffectiveMin   types.Int64              `tfsdk:"effective_min"`
+	Timeouts       timeouts.Value           `tfsdk:"timeouts"`
+	ScalableID     types.String             `tfsdk:"scalable_id"`
+	Endpoint       types.String             `tfsdk:"endpoint"`
+	AllowScaleDown types.Bool               `tfsdk:"allow_scale_down_during_event"`
+	suspendModel
+	ownershipModel
+	scalableStatusModel
 }
 
 type ecsScalingCapacityModel struct {
-	Min     types.Int64 `tfsdk:"low"`
+	Low     types.Int64 `tfsdk:"low"`
 	Medium  types.Int64 `tfsdk:"medium"`
 	High    types.Int64 `tfsdk:"high"`
 	Extreme types.Int64 `tfsdk:"extreme"`
 }
 
 func (m *ecsScalingResourceModel) ToClientModel() (string, client.EcsServicePostBody) {
+	suspended, suspendedUntil := m.suspendModel.toClientModel()
+	tags, owner, contact := m.ownershipModel.toClientModel()
 	return m.ServiceID.ValueString(), client.EcsServicePostBody{
-		MinLowCapacity:     m.MinTasks.Min.ValueInt64(),
+		MinLowCapacity:     m.MinTasks.Low.ValueInt64(),
 		MinMediumCapacity:  m.MinTasks.Medium.ValueInt64(),
 		MinHighCapacity:    m.MinTasks.High.ValueInt64(),
 		MinExtremeCapacity: m.MinTasks.Extreme.ValueInt64(),
 		Region:             m.Region.ValueString(),
+		Suspended:          suspended,
+		SuspendedUntil:     suspendedUntil,
+		Tags:               tags,
+		Owner:              owner,
+		Contact:            contact,
 	}
 }
 
-func ToECSResourceModel(m *client.EcsServiceResponse) ecsScalingResourceModel {
+// isApplied reports whether SSS has applied the minimum tasks of the current level.
+func (m *ecsScalingResourceModel) isApplied(status *client.ScalableStatusResponse) bool {
+	_, capacities := m.ToClientModel()
+	minTasks, ok := map[string]int64{
+		"low":     capacities.MinLowCapacity,
+		"medium":  capacities.MinMediumCapacity,
+		"high":    capacities.MinHighCapacity,
+		"extreme": capacities.MinExtremeCapacity,
+	}[status.CurrentLevel]
+	return ok && status.EffectiveMin == minTasks
+}
+
+// setStatus populates the computed status attributes.
+func (m *ecsScalingResourceModel) setStatus(status *client.ScalableStatusResponse) {
+	m.scalableStatusModel = newScalableStatusModel(status)
+	m.EffectiveMin = types.Int64Null()
+	if status != nil {
+		m.EffectiveMin = types.Int64Value(status.EffectiveMin)
+	}
+}
+
+// ToECSResourceModel converts an SSS registration to the resource model. Tags
+// inherited from defaultTags are left out of tags, and only kept there while
+// defaultTags is nil because the default tags are not known.
+func ToECSResourceModel(m *client.EcsServiceResponse, defaultTags map[string]string) ecsScalingResourceModel {
 	return ecsScalingResourceModel{
-		ServiceID: types.StringValue(m.Name),
-		Region:    types.StringValue(m.Region),
+		ScalableID: types.StringValue(m.Name),
+		ServiceID:  types.StringValue(m.Name),
+		Region:     types.StringValue(m.Region),
 		MinTasks: &ecsScalingCapacityModel{
-			Min:     types.Int64Value(m.MinLowCapacity),
+			Low:     types.Int64Value(m.MinLowCapacity),
 			Medium:  types.Int64Value(m.MinMediumCapacity),
 			High:    types.Int64Value(m.MinHighCapacity),
 			Extreme: types.Int64Value(m.MinExtremeCapacity),
 		},
+		Timeouts:       nullScalableTimeouts(),
+		suspendModel:   newSuspendModel(m.Suspended, m.SuspendedUntil, suspendModel{}),
+		ownershipModel: newOwnershipModel(m.Tags, m.Owner, m.Contact, defaultTags, types.MapNull(types.StringType)),
 	}
 }
 
@@ -70,42 +123,61 @@ func (r *ecsScalingResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.SssClient)
+	data, ok := req.ProviderData.(*resourceData)
 
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *client.SssClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *provider.resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
 
 		return
 	}
-	r.client = client
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+	r.guardrails = data.guardrails
 }
 
 // ecsScalingResource is the resource implementation.
 type ecsScalingResource struct {
-	client *client.SssClient
+	client      *client.SssClient
+	defaultTags map[string]string
+	guardrails  guardrailsModel
 }
 
 // Metadata returns the resource type name.
 func (r *ecsScalingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_ecs_scaling"
+	// A region change that stays on the same SSS host is applied in place,
+	// and changes the region of the identity.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
 // Schema defines the schema for the resource.
-func (r *ecsScalingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *ecsScalingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     scalableSchemaVersion,
 		Description: "Manages scaling for ECS services.",
 		Attributes: map[string]schema.Attribute{
 			"service_id": schema.StringAttribute{
 				Description: "The service ID. Should be in format CLUSTER_NAME/SERICE_NAME",
 				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"region": schema.StringAttribute{
 				Description: "The AWS region the service is located in. E.g. eu-west-1",
 				Required:    true,
 			},
+			"scalable_id":                   scalableIDAttribute(),
+			"endpoint":                      endpointAttribute(),
+			"allow_scale_down_during_event": allowScaleDownAttribute(),
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"wait_for_apply": waitForApplySchema(),
+			"effective_min": schema.Int64Attribute{
+				Description: "The minimum number of tasks SSS currently applies for current_level.",
+				Computed:    true,
+			},
 			"min_tasks": schema.SingleNestedAttribute{
 				Description: "The minimum number of tasks to have during different schedules.",
 				Required:    true,
@@ -119,7 +191,13 @@ func (r *ecsScalingResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": scalableTimeoutsBlock(ctx),
+		},
 	}
+	maps.Copy(resp.Schema.Attributes, suspendAttributes())
+	maps.Copy(resp.Schema.Attributes, ownershipAttributes())
+	maps.Copy(resp.Schema.Attributes, scalableStatusAttributes())
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -130,22 +208,48 @@ func (r *ecsScalingResource) Create(ctx context.Context, req resource.CreateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeECS, plan.ServiceID)
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	plan.resolveTagsAll(r.defaultTags)
 	serviceName, capacities := plan.ToClientModel()
 
-	err := r.client.CreateEcsService(serviceName, capacities)
+	err := scalableClient(r.client, plan.Region, plan.Endpoint).CreateEcsService(ctx, serviceName, capacities)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create ECS service scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to create ECS service scaling", clientErrorDetail(err, "create"))
 		return
 	}
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	c := scalableClient(r.client, plan.Region, plan.Endpoint)
+	var waitErr error
+	if !plan.Suspended.ValueBool() {
+		waitErr = plan.WaitForApply.wait(ctx, c, client.ScalableTypeECS, plan.ServiceID.ValueString(), plan.isApplied)
+	}
+	status, diags := getScalableStatus(ctx, c, client.ScalableTypeECS, plan.ServiceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	plan.setStatus(status)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setSuspendedUntilPrivate(ctx, resp.Private, plan.suspendModel)...)
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeECS, plan.ServiceID, plan.Region, plan.Endpoint)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Failed to apply ECS service scaling", waitErr.Error())
+	}
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -156,24 +260,51 @@ func (r *ecsScalingResource) Read(ctx context.Context, req resource.ReadRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeECS, state.ServiceID)
 
-	response, err := r.client.GetEcsService(state.ServiceID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	response, err := scalableClient(r.client, state.Region, state.Endpoint).GetEcsService(ctx, state.ServiceID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		// The registration was removed outside Terraform, or never created
+		// because the provider ran in dry run mode.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read ECS service scaling", "Could not read scaling for service "+state.ServiceID.ValueString()+": "+err.Error())
+		resp.Diagnostics.AddError("Failed to read ECS service scaling", "Could not read scaling for service "+state.ServiceID.ValueString()+": "+clientErrorDetail(err, "read"))
 		return
 	}
 
-	newState := ToECSResourceModel(response)
+	newState := ToECSResourceModel(response, r.defaultTags)
 
 	if !state.LastUpdated.IsNull() {
 		newState.LastUpdated = state.LastUpdated
 	}
+	newState.WaitForApply = state.WaitForApply
+	newState.Timeouts = state.Timeouts
+	newState.Endpoint = state.Endpoint
+	newState.AllowScaleDown = state.AllowScaleDown
+	newState.suspendModel = newSuspendModel(response.Suspended, response.SuspendedUntil, state.suspendModel)
+	newState.ownershipModel = newOwnershipModel(response.Tags, response.Owner, response.Contact, r.defaultTags, state.Tags)
+
+	status, diags := getScalableStatus(ctx, scalableClient(r.client, state.Region, state.Endpoint), client.ScalableTypeECS, newState.ServiceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	newState.setStatus(status)
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeECS, newState.ServiceID, newState.Region, newState.Endpoint)...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
@@ -184,20 +315,82 @@ func (r *ecsScalingResource) Update(ctx context.Context, req resource.UpdateRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeECS, plan.ServiceID)
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
 
+	plan.resolveTagsAll(r.defaultTags)
 	serviceName, capacities := plan.ToClientModel()
-	err := r.client.UpdateEcsService(serviceName, capacities)
+	err := scalableClient(r.client, plan.Region, plan.Endpoint).UpdateEcsService(ctx, serviceName, capacities)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update ECS service scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to update ECS service scaling", clientErrorDetail(err, "update"))
 		return
 	}
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	c := scalableClient(r.client, plan.Region, plan.Endpoint)
+	var waitErr error
+	if !plan.Suspended.ValueBool() {
+		waitErr = plan.WaitForApply.wait(ctx, c, client.ScalableTypeECS, plan.ServiceID.ValueString(), plan.isApplied)
+	}
+	status, diags := getScalableStatus(ctx, c, client.ScalableTypeECS, plan.ServiceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	plan.setStatus(status)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setSuspendedUntilPrivate(ctx, resp.Private, plan.suspendModel)...)
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeECS, plan.ServiceID, plan.Region, plan.Endpoint)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Failed to apply ECS service scaling", waitErr.Error())
+	}
+}
+
+// ModifyPlan plans scalable_id, endpoint and tags_all, forces replacement
+// when a region change moves the scalable to another SSS endpoint, checks
+// suspended_until, enforces the provider guardrails, and checks that an
+// update does not lower the minimum capacity of the level SSS currently
+// applies.
+func (r *ecsScalingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanScalableID(ctx, path.Root("service_id"), req, resp)
+	modifyPlanEndpoint(ctx, req, resp)
+	planRegionReplacement(ctx, r.client, req, resp)
+	modifyPlanSuspendedUntil(ctx, req, resp)
+	modifyPlanTagsAll(ctx, r.defaultTags, req, resp)
+	r.guardrails.modifyPlan(ctx, []guardedCapacity{
+		{path: func(level string) path.Path { return path.Root("min_tasks").AtName(level) }, limit: r.guardrails.MaxEcsTasks, limitName: "max_ecs_tasks", minimum: true},
+	}, req, resp)
+	modifyPlanScaleDown(ctx, r.client, client.ScalableTypeECS, path.Root("service_id"), func(level string) []path.Path {
+		return []path.Path{path.Root("min_tasks").AtName(level)}
+	}, req, resp)
+}
+
+// UpgradeState upgrades state written by earlier schema versions.
+func (r *ecsScalingResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return scalableStateUpgraders("service_id", nil)
+}
+
+// MoveState moves state from restapi_object and renamed SSS resource types.
+func (r *ecsScalingResource) MoveState(_ context.Context) []resource.StateMover {
+	return scalableMove[client.EcsServiceResponse]{
+		scalableType: client.ScalableTypeECS,
+		idAttribute:  "service_id",
+		idField:      "name",
+		id:           func(m *client.EcsServiceResponse) string { return m.Name },
+		region:       func(m *client.EcsServiceResponse) string { return m.Region },
+		toModel:      func(m *client.EcsServiceResponse) any { return ToECSResourceModel(m, nil) },
+	}.movers()
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -208,13 +401,28 @@ func (r *ecsScalingResource) Delete(ctx context.Context, req resource.DeleteRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := r.client.DeleteEcsService(state.ServiceID.ValueString())
+	ctx = withScalableLogFields(ctx, client.ScalableTypeECS, state.ServiceID)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := scalableClient(r.client, state.Region, state.Endpoint).DeleteEcsService(ctx, state.ServiceID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete ECS service scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to delete ECS service scaling", clientErrorDetail(err, "delete"))
 		return
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *ecsScalingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scalableIdentitySchema()
+}
+
 func (r *ecsScalingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("service_id"), req, resp)
+	importScalableState(ctx, client.ScalableTypeECS, path.Root("service_id"), req, resp)
 }
@@ -0,0 +1,67 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &eksHpaScalingListResource{}
+	_ list.ListResourceWithConfigure = &eksHpaScalingListResource{}
+)
+
+// NewEksHpaScalingListResource is a helper function to simplify the provider implementation.
+func NewEksHpaScalingListResource() list.ListResource {
+	return &eksHpaScalingListResource{}
+}
+
+// eksHpaScalingListResource is the list resource implementation.
+type eksHpaScalingListResource struct {
+	client      *client.SssClient
+	defaultTags map[string]string
+}
+
+func (r *eksHpaScalingListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*resourceData)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected List Resource Configure Type", fmt.Sprintf("Expected *provider.resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *eksHpaScalingListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_eks_hpa_scaling"
+}
+
+// ListResourceConfigSchema defines the schema for the list configuration.
+func (r *eksHpaScalingListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = scalableListConfigSchema("Lists EKS HPA scaling registrations.")
+}
+
+// List streams the registrations found in SSS.
+func (r *eksHpaScalingListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	scalableListing[client.EksHpaResponse]{
+		scalableType: client.ScalableTypeEKSHPA,
+		name:         "EKS HPA scaling",
+		list:         (*client.SssClient).ListEksHpas,
+		id:           func(m *client.EksHpaResponse) string { return m.ID },
+		region:       func(m *client.EksHpaResponse) string { return m.Region },
+		tags:         func(m *client.EksHpaResponse) map[string]string { return m.Tags },
+		toModel:      func(m *client.EksHpaResponse) any { return ToEksHpaResourceModel(m, r.defaultTags) },
+	}.stream(ctx, r.client, req, stream)
+}
@@ -5,32 +5,50 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"terraform-provider-sss/internal/client"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &eksHpaScalingResource{}
-	_ resource.ResourceWithConfigure   = &eksHpaScalingResource{}
-	_ resource.ResourceWithImportState = &eksHpaScalingResource{}
+	_ resource.Resource                 = &eksHpaScalingResource{}
+	_ resource.ResourceWithConfigure    = &eksHpaScalingResource{}
+	_ resource.ResourceWithImportState  = &eksHpaScalingResource{}
+	_ resource.ResourceWithIdentity     = &eksHpaScalingResource{}
+	_ resource.ResourceWithModifyPlan   = &eksHpaScalingResource{}
+	_ resource.ResourceWithUpgradeState = &eksHpaScalingResource{}
+	_ resource.ResourceWithMoveState    = &eksHpaScalingResource{}
 )
 
 type eksHpaScalingResourceModel struct {
-	ServiceID   types.String            `tfsdk:"service_id"`
-	Cluster     types.String            `tfsdk:"cluster"`
-	Region      types.String            `tfsdk:"region"`
-	Namespace   types.String            `tfsdk:"namespace"`
-	Name        types.String            `tfsdk:"name"`
-	Kind        types.String            `tfsdk:"kind"`
-	MinReplicas *eksHpaMinReplicasModel `tfsdk:"min_replicas"`
-	LastUpdated types.String            `tfsdk:"last_updated"`
+	ServiceID      types.String            `tfsdk:"service_id"`
+	Cluster        types.String            `tfsdk:"cluster"`
+	Region         types.String            `tfsdk:"region"`
+	Namespace      types.String            `tfsdk:"namespace"`
+	Name           types.String            `tfsdk:"name"`
+	Kind           types.String            `tfsdk:"kind"`
+	MinReplicas    *eksHpaMinReplicasModel `tfsdk:"min_replicas"`
+	LastUpdated    types.String            `tfsdk:"last_updated"`
+	WaitForApply   *waitForApplyModel      `tfsdk:"wait_for_apply"`
+	EffectiveMin   types.Int64             `tfsdk:"effective_min"`
+	Timeouts       timeouts.Value          `tfsdk:"timeouts"`
+	ScalableID     types.String            `tfsdk:"scalable_id"`
+	Endpoint       types.String            `tfsdk:"endpoint"`
+	AllowScaleDown types.Bool              `tfsdk:"allow_scale_down_during_event"`
+	suspendModel
+	ownershipModel
+	scalableStatusModel
 }
 
 type eksHpaMinReplicasModel struct {
@@ -41,33 +59,68 @@ type eksHpaMinReplicasModel struct {
 }
 
 func (m *eksHpaScalingResourceModel) ToClientModel() (string, client.EksHpaPostBody) {
+	suspended, suspendedUntil := m.suspendModel.toClientModel()
+	tags, owner, contact := m.ownershipModel.toClientModel()
 	return m.ServiceID.ValueString(), client.EksHpaPostBody{
-		Cluster:    m.Cluster.ValueString(),
-		Region:     m.Region.ValueString(),
-		Namespace:  m.Namespace.ValueString(),
-		Name:       m.Name.ValueString(),
-		Kind:       m.Kind.ValueString(),
-		MinLow:     m.MinReplicas.Low.ValueInt64(),
-		MinMedium:  m.MinReplicas.Medium.ValueInt64(),
-		MinHigh:    m.MinReplicas.High.ValueInt64(),
-		MinExtreme: m.MinReplicas.Extreme.ValueInt64(),
+		Cluster:        m.Cluster.ValueString(),
+		Region:         m.Region.ValueString(),
+		Namespace:      m.Namespace.ValueString(),
+		Name:           m.Name.ValueString(),
+		Kind:           m.Kind.ValueString(),
+		MinLow:         m.MinReplicas.Low.ValueInt64(),
+		MinMedium:      m.MinReplicas.Medium.ValueInt64(),
+		MinHigh:        m.MinReplicas.High.ValueInt64(),
+		MinExtreme:     m.MinReplicas.Extreme.ValueInt64(),
+		Suspended:      suspended,
+		SuspendedUntil: suspendedUntil,
+		Tags:           tags,
+		Owner:          owner,
+		Contact:        contact,
 	}
 }
 
-func ToEksHpaResourceModel(m *client.EksHpaResponse) eksHpaScalingResourceModel {
+// isApplied reports whether SSS has applied the minimum replicas of the current level.
+func (m *eksHpaScalingResourceModel) isApplied(status *client.ScalableStatusResponse) bool {
+	_, body := m.ToClientModel()
+	minReplicas, ok := map[string]int64{
+		"low":     body.MinLow,
+		"medium":  body.MinMedium,
+		"high":    body.MinHigh,
+		"extreme": body.MinExtreme,
+	}[status.CurrentLevel]
+	return ok && status.EffectiveMin == minReplicas
+}
+
+// setStatus populates the computed status attributes.
+func (m *eksHpaScalingResourceModel) setStatus(status *client.ScalableStatusResponse) {
+	m.scalableStatusModel = newScalableStatusModel(status)
+	m.EffectiveMin = types.Int64Null()
+	if status != nil {
+		m.EffectiveMin = types.Int64Value(status.EffectiveMin)
+	}
+}
+
+// ToEksHpaResourceModel converts an SSS registration to the resource model. Tags
+// inherited from defaultTags are left out of tags, and only kept there while
+// defaultTags is nil because the default tags are not known.
+func ToEksHpaResourceModel(m *client.EksHpaResponse, defaultTags map[string]string) eksHpaScalingResourceModel {
 	return eksHpaScalingResourceModel{
-		ServiceID: types.StringValue(m.ID),
-		Cluster:   types.StringValue(m.Cluster),
-		Region:    types.StringValue(m.Region),
-		Namespace: types.StringValue(m.Namespace),
-		Name:      types.StringValue(m.Name),
-		Kind:      types.StringValue(m.Kind),
+		ScalableID: types.StringValue(m.ID),
+		ServiceID:  types.StringValue(m.ID),
+		Cluster:    types.StringValue(m.Cluster),
+		Region:     types.StringValue(m.Region),
+		Namespace:  types.StringValue(m.Namespace),
+		Name:       types.StringValue(m.Name),
+		Kind:       types.StringValue(m.Kind),
 		MinReplicas: &eksHpaMinReplicasModel{
 			Low:     types.Int64Value(m.MinLow),
 			Medium:  types.Int64Value(m.MinMedium),
 			High:    types.Int64Value(m.MinHigh),
 			Extreme: types.Int64Value(m.MinExtreme),
 		},
+		Timeouts:       nullScalableTimeouts(),
+		suspendModel:   newSuspendModel(m.Suspended, m.SuspendedUntil, suspendModel{}),
+		ownershipModel: newOwnershipModel(m.Tags, m.Owner, m.Contact, defaultTags, types.MapNull(types.StringType)),
 	}
 }
 
@@ -76,7 +129,9 @@ func NewEksHpaScalingResource() resource.Resource {
 }
 
 type eksHpaScalingResource struct {
-	client *client.SssClient
+	client      *client.SssClient
+	defaultTags map[string]string
+	guardrails  guardrailsModel
 }
 
 func (r *eksHpaScalingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -84,25 +139,36 @@ func (r *eksHpaScalingResource) Configure(_ context.Context, req resource.Config
 		return
 	}
 
-	c, ok := req.ProviderData.(*client.SssClient)
+	data, ok := req.ProviderData.(*resourceData)
 	if !ok {
-		resp.Diagnostics.AddError("Unexpected Data Source Configure Type", fmt.Sprintf("Expected *client.SssClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type", fmt.Sprintf("Expected *provider.resourceData, got: %T. Please report this issue to the provider developers.", req.ProviderData))
 		return
 	}
-	r.client = c
+	r.client = data.client
+	r.defaultTags = data.defaultTags
+	r.guardrails = data.guardrails
 }
 
 func (r *eksHpaScalingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_eks_hpa_scaling"
+	// A region change that stays on the same SSS host is applied in place,
+	// and changes the region of the identity.
+	resp.ResourceBehavior.MutableIdentity = true
 }
 
-func (r *eksHpaScalingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *eksHpaScalingResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     scalableSchemaVersion,
 		Description: "Manages scheduled minReplicas for an EKS HorizontalPodAutoscaler or KEDA ScaledObject.",
 		Attributes: map[string]schema.Attribute{
 			"service_id": schema.StringAttribute{
-				Description: "The SSS scalable ID used as the URL path component. The provider convention is \"{namespace}/{name}@{cluster}\", but any unique string is accepted.",
-				Required:    true,
+				Description: "The SSS scalable ID used as the URL path component. The provider convention is \"{namespace}/{name}@{cluster}\", but any unique string is accepted. Computed from namespace, name and cluster when omitted.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					eksServiceIDDefault{},
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"cluster": schema.StringAttribute{
 				Description: "The EKS cluster name containing the target resource.",
@@ -124,9 +190,17 @@ func (r *eksHpaScalingResource) Schema(_ context.Context, _ resource.SchemaReque
 				Description: "The Kubernetes kind to scale. Must be \"HPA\" or \"ScaledObject\" — StatefulSet is deliberately unsupported.",
 				Required:    true,
 			},
+			"scalable_id":                   scalableIDAttribute(),
+			"endpoint":                      endpointAttribute(),
+			"allow_scale_down_during_event": allowScaleDownAttribute(),
 			"last_updated": schema.StringAttribute{
 				Computed: true,
 			},
+			"wait_for_apply": waitForApplySchema(),
+			"effective_min": schema.Int64Attribute{
+				Description: "The minimum number of replicas SSS currently applies for current_level.",
+				Computed:    true,
+			},
 			"min_replicas": schema.SingleNestedAttribute{
 				Description: "The minimum number of replicas to enforce at each schedule level.",
 				Required:    true,
@@ -138,7 +212,50 @@ func (r *eksHpaScalingResource) Schema(_ context.Context, _ resource.SchemaReque
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": scalableTimeoutsBlock(ctx),
+		},
+	}
+	maps.Copy(resp.Schema.Attributes, suspendAttributes())
+	maps.Copy(resp.Schema.Attributes, ownershipAttributes())
+	maps.Copy(resp.Schema.Attributes, scalableStatusAttributes())
+}
+
+// eksServiceIDDefault plans service_id as "{namespace}/{name}@{cluster}" when
+// it is omitted from the configuration.
+type eksServiceIDDefault struct{}
+
+func (m eksServiceIDDefault) Description(_ context.Context) string {
+	return "Defaults to \"{namespace}/{name}@{cluster}\" when not configured."
+}
+
+func (m eksServiceIDDefault) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m eksServiceIDDefault) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() {
+		return
 	}
+
+	var namespace, name, cluster types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("namespace"), &namespace)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("cluster"), &cluster)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if namespace.IsUnknown() || name.IsUnknown() || cluster.IsUnknown() {
+		resp.PlanValue = types.StringUnknown()
+		return
+	}
+
+	resp.PlanValue = types.StringValue(formatEksServiceID(eksServiceIDParts{
+		Namespace: namespace.ValueString(),
+		Name:      name.ValueString(),
+		Cluster:   cluster.ValueString(),
+	}))
 }
 
 func (r *eksHpaScalingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -148,22 +265,48 @@ func (r *eksHpaScalingResource) Create(ctx context.Context, req resource.CreateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeEKSHPA, plan.ServiceID)
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
+	plan.resolveTagsAll(r.defaultTags)
 	serviceId, body := plan.ToClientModel()
 
-	err := r.client.CreateEksHpa(serviceId, body)
+	err := scalableClient(r.client, plan.Region, plan.Endpoint).CreateEksHpa(ctx, serviceId, body)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to create EKS HPA scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to create EKS HPA scaling", clientErrorDetail(err, "create"))
 		return
 	}
 
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	c := scalableClient(r.client, plan.Region, plan.Endpoint)
+	var waitErr error
+	if !plan.Suspended.ValueBool() {
+		waitErr = plan.WaitForApply.wait(ctx, c, client.ScalableTypeEKSHPA, plan.ServiceID.ValueString(), plan.isApplied)
+	}
+	status, diags := getScalableStatus(ctx, c, client.ScalableTypeEKSHPA, plan.ServiceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	plan.setStatus(status)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setSuspendedUntilPrivate(ctx, resp.Private, plan.suspendModel)...)
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeEKSHPA, plan.ServiceID, plan.Region, plan.Endpoint)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Failed to apply EKS HPA scaling", waitErr.Error())
+	}
 }
 
 func (r *eksHpaScalingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -173,24 +316,51 @@ func (r *eksHpaScalingResource) Read(ctx context.Context, req resource.ReadReque
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeEKSHPA, state.ServiceID)
 
-	response, err := r.client.GetEksHpa(state.ServiceID.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	response, err := scalableClient(r.client, state.Region, state.Endpoint).GetEksHpa(ctx, state.ServiceID.ValueString())
+	if errors.Is(err, client.ErrNotFound) {
+		// The registration was removed outside Terraform, or never created
+		// because the provider ran in dry run mode.
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to read EKS HPA scaling", "Could not read scaling for "+state.ServiceID.ValueString()+": "+err.Error())
+		resp.Diagnostics.AddError("Failed to read EKS HPA scaling", "Could not read scaling for "+state.ServiceID.ValueString()+": "+clientErrorDetail(err, "read"))
 		return
 	}
 
-	newState := ToEksHpaResourceModel(response)
+	newState := ToEksHpaResourceModel(response, r.defaultTags)
 
 	if !state.LastUpdated.IsNull() {
 		newState.LastUpdated = state.LastUpdated
 	}
+	newState.WaitForApply = state.WaitForApply
+	newState.Timeouts = state.Timeouts
+	newState.Endpoint = state.Endpoint
+	newState.AllowScaleDown = state.AllowScaleDown
+	newState.suspendModel = newSuspendModel(response.Suspended, response.SuspendedUntil, state.suspendModel)
+	newState.ownershipModel = newOwnershipModel(response.Tags, response.Owner, response.Contact, r.defaultTags, state.Tags)
+
+	status, diags := getScalableStatus(ctx, scalableClient(r.client, state.Region, state.Endpoint), client.ScalableTypeEKSHPA, newState.ServiceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	newState.setStatus(status)
 
 	diags = resp.State.Set(ctx, &newState)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeEKSHPA, newState.ServiceID, newState.Region, newState.Endpoint)...)
 }
 
 func (r *eksHpaScalingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -200,20 +370,82 @@ func (r *eksHpaScalingResource) Update(ctx context.Context, req resource.UpdateR
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	ctx = withScalableLogFields(ctx, client.ScalableTypeEKSHPA, plan.ServiceID)
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	plan.resolveTagsAll(r.defaultTags)
 	serviceId, body := plan.ToClientModel()
-	err := r.client.UpdateEksHpa(serviceId, body)
+	err := scalableClient(r.client, plan.Region, plan.Endpoint).UpdateEksHpa(ctx, serviceId, body)
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update EKS HPA scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to update EKS HPA scaling", clientErrorDetail(err, "update"))
 		return
 	}
 	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
+	c := scalableClient(r.client, plan.Region, plan.Endpoint)
+	var waitErr error
+	if !plan.Suspended.ValueBool() {
+		waitErr = plan.WaitForApply.wait(ctx, c, client.ScalableTypeEKSHPA, plan.ServiceID.ValueString(), plan.isApplied)
+	}
+	status, diags := getScalableStatus(ctx, c, client.ScalableTypeEKSHPA, plan.ServiceID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	plan.setStatus(status)
+
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	resp.Diagnostics.Append(setSuspendedUntilPrivate(ctx, resp.Private, plan.suspendModel)...)
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, client.ScalableTypeEKSHPA, plan.ServiceID, plan.Region, plan.Endpoint)...)
+
+	if waitErr != nil {
+		resp.Diagnostics.AddError("Failed to apply EKS HPA scaling", waitErr.Error())
+	}
+}
+
+// ModifyPlan plans scalable_id, endpoint and tags_all, forces replacement
+// when a region change moves the scalable to another SSS endpoint, checks
+// suspended_until, enforces the provider guardrails, and checks that an
+// update does not lower the minimum capacity of the level SSS currently
+// applies.
+func (r *eksHpaScalingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanScalableID(ctx, path.Root("service_id"), req, resp)
+	modifyPlanEndpoint(ctx, req, resp)
+	planRegionReplacement(ctx, r.client, req, resp)
+	modifyPlanSuspendedUntil(ctx, req, resp)
+	modifyPlanTagsAll(ctx, r.defaultTags, req, resp)
+	r.guardrails.modifyPlan(ctx, []guardedCapacity{
+		{path: func(level string) path.Path { return path.Root("min_replicas").AtName(level) }, limit: r.guardrails.MaxEksReplicas, limitName: "max_eks_replicas", minimum: true},
+	}, req, resp)
+	modifyPlanScaleDown(ctx, r.client, client.ScalableTypeEKSHPA, path.Root("service_id"), func(level string) []path.Path {
+		return []path.Path{path.Root("min_replicas").AtName(level)}
+	}, req, resp)
+}
+
+// UpgradeState upgrades state written by earlier schema versions.
+func (r *eksHpaScalingResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return scalableStateUpgraders("service_id", nil)
+}
+
+// MoveState moves state from restapi_object and renamed SSS resource types.
+func (r *eksHpaScalingResource) MoveState(_ context.Context) []resource.StateMover {
+	return scalableMove[client.EksHpaResponse]{
+		scalableType: client.ScalableTypeEKSHPA,
+		idAttribute:  "service_id",
+		idField:      "id",
+		id:           func(m *client.EksHpaResponse) string { return m.ID },
+		region:       func(m *client.EksHpaResponse) string { return m.Region },
+		toModel:      func(m *client.EksHpaResponse) any { return ToEksHpaResourceModel(m, nil) },
+	}.movers()
 }
 
 func (r *eksHpaScalingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -223,13 +455,28 @@ func (r *eksHpaScalingResource) Delete(ctx context.Context, req resource.DeleteR
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	_, err := r.client.DeleteEksHpa(state.ServiceID.ValueString())
+	ctx = withScalableLogFields(ctx, client.ScalableTypeEKSHPA, state.ServiceID)
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultScalableTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	_, err := scalableClient(r.client, state.Region, state.Endpoint).DeleteEksHpa(ctx, state.ServiceID.ValueString())
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to delete EKS HPA scaling", err.Error())
+		resp.Diagnostics.AddError("Failed to delete EKS HPA scaling", clientErrorDetail(err, "delete"))
 		return
 	}
 }
 
+// IdentitySchema defines the identity schema for the resource.
+func (r *eksHpaScalingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = scalableIdentitySchema()
+}
+
 func (r *eksHpaScalingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("service_id"), req, resp)
+	importScalableState(ctx, client.ScalableTypeEKSHPA, path.Root("service_id"), req, resp)
 }
@@ -0,0 +1,126 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &eksServiceIDFunction{}
+
+// eksServiceIDParts holds the components of an EKS HPA service ID in the
+// "{namespace}/{name}@{cluster}" convention.
+type eksServiceIDParts struct {
+	Namespace string `tfsdk:"namespace"`
+	Name      string `tfsdk:"name"`
+	Cluster   string `tfsdk:"cluster"`
+}
+
+// validateEksServiceIDPart ensures a single component can be used in a service
+// ID without making it ambiguous to parse.
+func validateEksServiceIDPart(field string, value string) error {
+	if value == "" {
+		return fmt.Errorf("%s must not be empty", field)
+	}
+	if strings.ContainsAny(value, "/@") {
+		return fmt.Errorf("%s %q must not contain \"/\" or \"@\"", field, value)
+	}
+	return nil
+}
+
+// formatEksServiceID joins the components into "{namespace}/{name}@{cluster}".
+func formatEksServiceID(parts eksServiceIDParts) string {
+	return parts.Namespace + "/" + parts.Name + "@" + parts.Cluster
+}
+
+// parseEksServiceID splits a "{namespace}/{name}@{cluster}" service ID into
+// its components.
+func parseEksServiceID(id string) (eksServiceIDParts, error) {
+	workload, cluster, found := strings.Cut(id, "@")
+	if !found {
+		return eksServiceIDParts{}, fmt.Errorf("service ID %q is missing the \"@{cluster}\" suffix, expected format \"{namespace}/{name}@{cluster}\"", id)
+	}
+	namespace, name, found := strings.Cut(workload, "/")
+	if !found {
+		return eksServiceIDParts{}, fmt.Errorf("service ID %q is missing the \"/\" between namespace and name, expected format \"{namespace}/{name}@{cluster}\"", id)
+	}
+
+	parts := eksServiceIDParts{Namespace: namespace, Name: name, Cluster: cluster}
+	for _, err := range []error{
+		validateEksServiceIDPart("namespace", parts.Namespace),
+		validateEksServiceIDPart("name", parts.Name),
+		validateEksServiceIDPart("cluster", parts.Cluster),
+	} {
+		if err != nil {
+			return eksServiceIDParts{}, fmt.Errorf("invalid service ID %q: %w", id, err)
+		}
+	}
+	return parts, nil
+}
+
+// NewEksServiceIDFunction is a helper function to simplify the provider implementation.
+func NewEksServiceIDFunction() function.Function {
+	return &eksServiceIDFunction{}
+}
+
+// eksServiceIDFunction is the function implementation.
+type eksServiceIDFunction struct{}
+
+// Metadata returns the function name.
+func (f *eksServiceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "eks_service_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *eksServiceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Build an sss_eks_hpa_scaling service ID.",
+		Description: "Joins a namespace, name and cluster into the \"{namespace}/{name}@{cluster}\" service ID convention used by sss_eks_hpa_scaling.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "namespace",
+				Description: "The Kubernetes namespace of the HPA or ScaledObject.",
+			},
+			function.StringParameter{
+				Name:        "name",
+				Description: "The name of the HorizontalPodAutoscaler or ScaledObject.",
+			},
+			function.StringParameter{
+				Name:        "cluster",
+				Description: "The EKS cluster name containing the target resource.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run validates the components and returns the joined service ID.
+func (f *eksServiceIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var parts eksServiceIDParts
+
+	resp.Error = req.Arguments.Get(ctx, &parts.Namespace, &parts.Name, &parts.Cluster)
+	if resp.Error != nil {
+		return
+	}
+
+	for i, err := range []error{
+		validateEksServiceIDPart("namespace", parts.Namespace),
+		validateEksServiceIDPart("name", parts.Name),
+		validateEksServiceIDPart("cluster", parts.Cluster),
+	} {
+		if err != nil {
+			resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(int64(i), err.Error()))
+		}
+	}
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, formatEksServiceID(parts))
+}
@@ -0,0 +1,61 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// endpointAttribute returns the endpoint attribute shared by all scaling
+// resources.
+func endpointAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The SSS host that manages the scalable, overriding the provider endpoint for its region. " +
+			"Set by importing with an endpoint qualifier, and kept when omitted from the configuration.",
+		Optional: true,
+		Computed: true,
+		PlanModifiers: []planmodifier.String{
+			stringplanmodifier.RequiresReplaceIfConfigured(),
+		},
+	}
+}
+
+// modifyPlanEndpoint keeps the endpoint in state when it is omitted from the
+// configuration, so an imported scalable stays on the SSS host it was
+// imported from.
+func modifyPlanEndpoint(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var endpoint types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("endpoint"), &endpoint)...)
+	if resp.Diagnostics.HasError() || !endpoint.IsNull() {
+		return
+	}
+
+	prior := types.StringNull()
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("endpoint"), &prior)...)
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("endpoint"), prior)...)
+}
+
+// scalableClient returns a client for the SSS host that manages a scalable:
+// its endpoint if set, or else the provider endpoint for its region.
+func scalableClient(c *client.SssClient, region types.String, endpoint types.String) *client.SssClient {
+	if endpoint.ValueString() != "" {
+		return c.ForHost(endpoint.ValueString())
+	}
+	return c.ForRegion(region.ValueString())
+}
@@ -0,0 +1,142 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// guardrailsModel holds the provider guardrails, bounds on the capacity of
+// every scaling resource. Unset guardrails are not enforced.
+type guardrailsModel struct {
+	MaxEcsTasks            types.Int64   `tfsdk:"max_ecs_tasks"`
+	MaxEksReplicas         types.Int64   `tfsdk:"max_eks_replicas"`
+	MaxDynamoWriteCapacity types.Int64   `tfsdk:"max_dynamo_write_capacity"`
+	MaxDynamoReadCapacity  types.Int64   `tfsdk:"max_dynamo_read_capacity"`
+	MaxExtremeLowRatio     types.Float64 `tfsdk:"max_extreme_low_ratio"`
+}
+
+// guardrailsBlock returns the guardrails block of the provider schema.
+func guardrailsBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		MarkdownDescription: "Bounds on the capacity of every scaling resource managed by the provider, checked when planning so a typo such as `extreme = 600` instead of `60` never reaches SSS. They are enforced by `terraform plan` and `terraform apply` only, not by `terraform validate`, which has no access to the provider configuration.",
+		Attributes: map[string]schema.Attribute{
+			"max_ecs_tasks": schema.Int64Attribute{
+				MarkdownDescription: "The maximum of `min_tasks` at any level of `sss_ecs_scaling`.",
+				Optional:            true,
+			},
+			"max_eks_replicas": schema.Int64Attribute{
+				MarkdownDescription: "The maximum of `min_replicas` at any level of `sss_eks_hpa_scaling`.",
+				Optional:            true,
+			},
+			"max_dynamo_write_capacity": schema.Int64Attribute{
+				MarkdownDescription: "The maximum of `min_write` and `max_write` at any level of `sss_dynamo_table_scaling`.",
+				Optional:            true,
+			},
+			"max_dynamo_read_capacity": schema.Int64Attribute{
+				MarkdownDescription: "The maximum of `min_read` and `max_read` at any level of `sss_dynamo_table_scaling`.",
+				Optional:            true,
+			},
+			"max_extreme_low_ratio": schema.Float64Attribute{
+				MarkdownDescription: "The maximum ratio of the `extreme` minimum capacity to the `low` minimum capacity of any scaling resource. A `low` minimum of 0 exceeds the ratio unless the `extreme` minimum is 0 as well.",
+				Optional:            true,
+			},
+		},
+	}
+}
+
+// validate checks that the configured guardrails can be met.
+func (g guardrailsModel) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+	for name, limit := range map[string]types.Int64{
+		"max_ecs_tasks":             g.MaxEcsTasks,
+		"max_eks_replicas":          g.MaxEksReplicas,
+		"max_dynamo_write_capacity": g.MaxDynamoWriteCapacity,
+		"max_dynamo_read_capacity":  g.MaxDynamoReadCapacity,
+	} {
+		if !limit.IsNull() && !limit.IsUnknown() && limit.ValueInt64() < 0 {
+			diags.AddAttributeError(path.Root("guardrails").AtName(name), "Invalid Guardrail", name+" must not be negative.")
+		}
+	}
+	if !g.MaxExtremeLowRatio.IsNull() && !g.MaxExtremeLowRatio.IsUnknown() && g.MaxExtremeLowRatio.ValueFloat64() < 1 {
+		diags.AddAttributeError(path.Root("guardrails").AtName("max_extreme_low_ratio"), "Invalid Guardrail", "max_extreme_low_ratio must be at least 1.")
+	}
+	return diags
+}
+
+// guardedCapacity is a capacity attribute of a scaling resource that is set
+// per level, and the guardrails it is bound by.
+type guardedCapacity struct {
+	// path returns the path of the capacity at a level.
+	path func(level string) path.Path
+	// limit bounds the capacity at every level, and limitName is the name
+	// of the guardrail attribute setting it.
+	limit     types.Int64
+	limitName string
+	// minimum is set for minimum capacities, which max_extreme_low_ratio
+	// also applies to.
+	minimum bool
+}
+
+// modifyPlan fails the plan when it sets a capacity beyond the guardrails.
+// The provider configuration is not available to ValidateConfig, so the
+// guardrails are enforced when planning.
+func (g guardrailsModel) modifyPlan(ctx context.Context, capacities []guardedCapacity, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	for _, capacity := range capacities {
+		values := map[string]int64{}
+		for _, level := range scaleLevelNames {
+			var value types.Int64
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, capacity.path(level), &value)...)
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			values[level] = value.ValueInt64()
+
+			if !capacity.limit.IsNull() && !capacity.limit.IsUnknown() && value.ValueInt64() > capacity.limit.ValueInt64() {
+				resp.Diagnostics.AddAttributeError(
+					capacity.path(level),
+					"Guardrail Exceeded",
+					fmt.Sprintf("%s is %d, above the provider guardrail %s = %d. Raise the guardrail if the capacity is intended.",
+						capacity.path(level), value.ValueInt64(), capacity.limitName, capacity.limit.ValueInt64()),
+				)
+			}
+		}
+
+		low, lowOk := values["low"]
+		extreme, extremeOk := values["extreme"]
+		if !capacity.minimum || !lowOk || !extremeOk || extreme <= 0 || g.MaxExtremeLowRatio.IsNull() || g.MaxExtremeLowRatio.IsUnknown() {
+			continue
+		}
+		// A low minimum of 0 makes any extreme minimum an unbounded ratio.
+		if low <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				capacity.path("extreme"),
+				"Guardrail Exceeded",
+				fmt.Sprintf("%s is %d while %s is %d, an unbounded ratio above the provider guardrail max_extreme_low_ratio = %g. Raise %s, or remove the guardrail if the capacity is intended.",
+					capacity.path("extreme"), extreme, capacity.path("low"), low, g.MaxExtremeLowRatio.ValueFloat64(), capacity.path("low")),
+			)
+			continue
+		}
+		if ratio := float64(extreme) / float64(low); ratio > g.MaxExtremeLowRatio.ValueFloat64() {
+			resp.Diagnostics.AddAttributeError(
+				capacity.path("extreme"),
+				"Guardrail Exceeded",
+				fmt.Sprintf("%s is %g times %s, above the provider guardrail max_extreme_low_ratio = %g. Raise the guardrail if the capacity is intended.",
+					capacity.path("extreme"), ratio, capacity.path("low"), g.MaxExtremeLowRatio.ValueFloat64()),
+			)
+		}
+	}
+}
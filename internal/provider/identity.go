@@ -0,0 +1,169 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// scalableIdentityModel describes the identity shared by all scaling resources.
+type scalableIdentityModel struct {
+	ScalableType types.String `tfsdk:"scalable_type"`
+	ScalableID   types.String `tfsdk:"scalable_id"`
+	Region       types.String `tfsdk:"region"`
+	Endpoint     types.String `tfsdk:"endpoint"`
+}
+
+// scalableIdentitySchema returns the identity schema shared by all scaling resources.
+func scalableIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"scalable_type": identityschema.StringAttribute{
+				Description:       "The SSS scalable type, e.g. ecs, dynamodbtable or eks-hpa. Defaults to the type of the resource being imported.",
+				OptionalForImport: true,
+			},
+			"scalable_id": identityschema.StringAttribute{
+				Description:       "The SSS scalable ID used as the URL path component.",
+				RequiredForImport: true,
+			},
+			"region": identityschema.StringAttribute{
+				Description:       "The AWS region of the scalable. E.g. eu-west-1.",
+				OptionalForImport: true,
+			},
+			"endpoint": identityschema.StringAttribute{
+				Description:       "The SSS host that manages the scalable, when it is not the provider endpoint of its region.",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+// setScalableIdentity stores the identity of a scalable in the response identity.
+func setScalableIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, scalableType client.ScalableType, scalableID types.String, region types.String, endpoint types.String) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, scalableIdentityModel{
+		ScalableType: types.StringValue(string(scalableType)),
+		ScalableID:   scalableID,
+		Region:       region,
+		Endpoint:     endpoint,
+	})
+}
+
+// importScalableState imports a scalable either by an import ID of the form
+// [REGION:]SCALABLE_ID[?endpoint=HOST] or by the identity attributes of an
+// import block. The region and endpoint are copied to state as well, so the
+// first Read is sent to the right SSS host.
+func importScalableState(ctx context.Context, scalableType client.ScalableType, idPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity scalableIdentityModel
+	if req.ID != "" {
+		id, err := parseScalableImportID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+			return
+		}
+		identity = scalableIdentityModel{
+			ScalableType: types.StringValue(string(scalableType)),
+			ScalableID:   types.StringValue(id.scalableID),
+			Region:       optionalStringValue(id.region),
+			Endpoint:     optionalStringValue(id.endpoint),
+		}
+		resp.Diagnostics.Append(setScalableIdentity(ctx, resp.Identity, scalableType, identity.ScalableID, identity.Region, identity.Endpoint)...)
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if !identity.ScalableType.IsNull() && identity.ScalableType.ValueString() != string(scalableType) {
+			resp.Diagnostics.AddError(
+				"Unexpected Scalable Type",
+				fmt.Sprintf("This resource manages scalables of type %q, got %q.", scalableType, identity.ScalableType.ValueString()),
+			)
+			return
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, idPath, identity.ScalableID)...)
+	if !identity.Region.IsNull() {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("region"), identity.Region)...)
+	}
+	if !identity.Endpoint.IsNull() {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("endpoint"), identity.Endpoint)...)
+	}
+}
+
+// scalableImportID is a parsed import ID.
+type scalableImportID struct {
+	scalableID string
+	region     string
+	endpoint   string
+}
+
+// parseScalableImportID parses an import ID of the form
+// [REGION:]SCALABLE_ID[?endpoint=HOST]. Scalable IDs never contain a colon,
+// so a colon always separates the region.
+func parseScalableImportID(importID string) (scalableImportID, error) {
+	const format = "expected [REGION:]SCALABLE_ID[?endpoint=HOST], e.g. eu-north-1:service/cluster/service"
+
+	var id scalableImportID
+	if strings.HasPrefix(importID, "arn:") {
+		return id, fmt.Errorf("import ID %q is an ARN, %s. The *_id_from_arn provider functions convert ARNs to scalable IDs", importID, format)
+	}
+
+	rest, query, hasQuery := strings.Cut(importID, "?")
+	if hasQuery {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return id, fmt.Errorf("invalid qualifiers in import ID %q, %s: %w", importID, format, err)
+		}
+		for key, value := range values {
+			if key != "endpoint" {
+				return id, fmt.Errorf("unsupported qualifier %q in import ID %q, only endpoint is supported", key, importID)
+			}
+			if len(value) != 1 || value[0] == "" {
+				return id, fmt.Errorf("the endpoint qualifier in import ID %q must have exactly one non-empty value", importID)
+			}
+			if err := client.ValidateHost(value[0]); err != nil {
+				return id, fmt.Errorf("invalid endpoint qualifier in import ID %q: %w", importID, err)
+			}
+			id.endpoint = value[0]
+		}
+	}
+
+	if region, scalableID, hasRegion := strings.Cut(rest, ":"); hasRegion {
+		if !awsRegionPattern.MatchString(region) {
+			return id, fmt.Errorf("invalid region %q in import ID %q, %s", region, importID, format)
+		}
+		id.region, rest = region, scalableID
+	}
+	if rest == "" {
+		return id, fmt.Errorf("missing scalable ID in import ID %q, %s", importID, format)
+	}
+	id.scalableID = rest
+	return id, nil
+}
+
+// optionalStringValue converts an empty string to null.
+func optionalStringValue(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
@@ -0,0 +1,72 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseScalableImportID(t *testing.T) {
+	tests := []struct {
+		importID string
+		want     scalableImportID
+		wantErr  string
+	}{
+		{importID: "service/cluster/service", want: scalableImportID{scalableID: "service/cluster/service"}},
+		{importID: "eu-north-1:table/entries", want: scalableImportID{scalableID: "table/entries", region: "eu-north-1"}},
+		{importID: "eusc-de-east-1:table/entries", want: scalableImportID{scalableID: "table/entries", region: "eusc-de-east-1"}},
+		{importID: "us-gov-west-1:ns/app@cluster", want: scalableImportID{scalableID: "ns/app@cluster", region: "us-gov-west-1"}},
+		{importID: "", wantErr: "missing scalable ID"},
+		{importID: "eu-north-1:", wantErr: "missing scalable ID"},
+		{importID: "arn:aws:ecs:eu-west-1:123456789012:service/cluster/service", wantErr: "is an ARN"},
+		{importID: "europe:service/cluster/service", wantErr: `invalid region "europe"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.importID, func(t *testing.T) {
+			testParseScalableImportID(t, tt.importID, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func TestParseScalableImportIDEndpoint(t *testing.T) {
+	tests := []struct {
+		importID string
+		want     scalableImportID
+		wantErr  string
+	}{
+		{importID: "service/cluster/service?endpoint=sss.example.com", want: scalableImportID{scalableID: "service/cluster/service", endpoint: "sss.example.com"}},
+		{importID: "eu-north-1:service/cluster/service?endpoint=sss.example.com:8443", want: scalableImportID{scalableID: "service/cluster/service", region: "eu-north-1", endpoint: "sss.example.com:8443"}},
+		{importID: "table/entries?endpoint=sss.example.com:8443/sss", want: scalableImportID{scalableID: "table/entries", endpoint: "sss.example.com:8443/sss"}},
+		{importID: "?endpoint=sss.example.com", wantErr: "missing scalable ID"},
+		{importID: "service/cluster/service?region=eu-north-1", wantErr: `unsupported qualifier "region"`},
+		{importID: "service/cluster/service?endpoint=", wantErr: "must have exactly one non-empty value"},
+		{importID: "service/cluster/service?endpoint=a.example.com&endpoint=b.example.com", wantErr: "must have exactly one non-empty value"},
+		{importID: "service/cluster/service?endpoint=%zz", wantErr: "invalid qualifiers"},
+		{importID: "service/cluster/service?endpoint=https://sss.example.com", wantErr: "invalid endpoint qualifier"},
+		{importID: "service/cluster/service?endpoint=:8443", wantErr: "invalid endpoint qualifier"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.importID, func(t *testing.T) {
+			testParseScalableImportID(t, tt.importID, tt.want, tt.wantErr)
+		})
+	}
+}
+
+func testParseScalableImportID(t *testing.T, importID string, want scalableImportID, wantErr string) {
+	t.Helper()
+	got, err := parseScalableImportID(importID)
+	if wantErr != "" {
+		if err == nil || !strings.Contains(err.Error(), wantErr) {
+			t.Fatalf("parseScalableImportID(%q) = %v, want error containing %q", importID, err, wantErr)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("parseScalableImportID(%q) returned error: %v", importID, err)
+	}
+	if got != want {
+		t.Fatalf("parseScalableImportID(%q) = %+v, want %+v", importID, got, want)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-sss/internal/client"
+
+	actionschema "github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// overrideScalableTypes lists the scalable types an override can target.
+var overrideScalableTypes = []string{
+	string(client.ScalableTypeECS),
+	string(client.ScalableTypeDynamoDB),
+	string(client.ScalableTypeEKSHPA),
+}
+
+// levelOverrideTargetModel describes the scalable or group an override action applies to.
+type levelOverrideTargetModel struct {
+	ScalableType types.String `tfsdk:"scalable_type"`
+	ScalableID   types.String `tfsdk:"scalable_id"`
+	Group        types.String `tfsdk:"group"`
+	Region       types.String `tfsdk:"region"`
+}
+
+// levelOverrideTargetAttributes returns the schema attributes shared by the override actions.
+func levelOverrideTargetAttributes() map[string]actionschema.Attribute {
+	return map[string]actionschema.Attribute{
+		"scalable_type": actionschema.StringAttribute{
+			MarkdownDescription: "The SSS scalable type of `scalable_id`, one of `" + strings.Join(overrideScalableTypes, "`, `") + "`. Required together with `scalable_id`.",
+			Optional:            true,
+		},
+		"scalable_id": actionschema.StringAttribute{
+			MarkdownDescription: "The SSS scalable ID, e.g. the `service_id` or `table_name` of a scaling resource. Conflicts with `group`.",
+			Optional:            true,
+		},
+		"group": actionschema.StringAttribute{
+			MarkdownDescription: "The SSS group whose scalables are all overridden. Conflicts with `scalable_id`.",
+			Optional:            true,
+		},
+		"region": actionschema.StringAttribute{
+			MarkdownDescription: "The AWS region of the scalable or group. E.g. eu-west-1. Selects the regional endpoint the request is sent to.",
+			Optional:            true,
+		},
+	}
+}
+
+// validate reports configuration errors in the target. Unknown values are
+// skipped, they are validated again once known.
+func (m levelOverrideTargetModel) validate() diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if m.ScalableID.IsUnknown() || m.Group.IsUnknown() {
+		return diags
+	}
+	switch {
+	case m.ScalableID.IsNull() && m.Group.IsNull():
+		diags.AddError("Missing Override Target", "Exactly one of scalable_id or group must be configured.")
+	case !m.ScalableID.IsNull() && !m.Group.IsNull():
+		diags.AddAttributeError(path.Root("group"), "Conflicting Override Target", "Exactly one of scalable_id or group must be configured.")
+	case !m.ScalableID.IsNull() && m.ScalableType.IsNull():
+		diags.AddAttributeError(path.Root("scalable_type"), "Missing Scalable Type", "scalable_type must be configured together with scalable_id.")
+	case !m.Group.IsNull() && !m.ScalableType.IsNull():
+		diags.AddAttributeError(path.Root("scalable_type"), "Unexpected Scalable Type", "scalable_type cannot be configured together with group.")
+	}
+
+	if !m.ScalableType.IsNull() && !m.ScalableType.IsUnknown() && !slices.Contains(overrideScalableTypes, m.ScalableType.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("scalable_type"),
+			"Invalid Scalable Type",
+			fmt.Sprintf("scalable_type must be one of %s, got %q.", strings.Join(overrideScalableTypes, ", "), m.ScalableType.ValueString()),
+		)
+	}
+	return diags
+}
+
+// description returns a human readable name of the target.
+func (m levelOverrideTargetModel) description() string {
+	if !m.Group.IsNull() {
+		return "group " + m.Group.ValueString()
+	}
+	return m.ScalableType.ValueString() + "/" + m.ScalableID.ValueString()
+}
@@ -0,0 +1,72 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOverrideActionsDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		actionType  string
+		config      map[string]tftypes.Value
+		dryRun      bool
+		wantMessage string
+	}{
+		{name: "set level", actionType: "sss_set_level", config: map[string]tftypes.Value{"level": tftypes.NewValue(tftypes.String, "high")}, wantMessage: "Switched ecs/service/cluster/app to level high"},
+		{name: "set level dry run", actionType: "sss_set_level", config: map[string]tftypes.Value{"level": tftypes.NewValue(tftypes.String, "high")}, dryRun: true, wantMessage: "Dry run, logged the switch of ecs/service/cluster/app to level high without sending it to SSS"},
+		{name: "clear override", actionType: "sss_clear_override", wantMessage: "Cleared the override of ecs/service/cluster/app"},
+		{name: "clear override dry run", actionType: "sss_clear_override", dryRun: true, wantMessage: "Dry run, logged clearing the override of ecs/service/cluster/app without sending it to SSS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sss := newFakeSSS(t)
+			p := newTestProvider(t, sss.host(), map[string]tftypes.Value{"dry_run": tftypes.NewValue(tftypes.Bool, tt.dryRun)})
+			values := map[string]tftypes.Value{
+				"scalable_type": tftypes.NewValue(tftypes.String, "ecs"),
+				"scalable_id":   tftypes.NewValue(tftypes.String, "service/cluster/app"),
+				"region":        tftypes.NewValue(tftypes.String, "eu-west-1"),
+			}
+			for name, value := range tt.config {
+				values[name] = value
+			}
+			actionType := p.schema.ActionSchemas[tt.actionType].Schema.ValueType().(tftypes.Object)
+			server, ok := p.server.(tfprotov6.ActionServer)
+			if !ok {
+				t.Fatalf("provider server %T does not serve actions", p.server)
+			}
+			stream, err := server.InvokeAction(context.Background(), &tfprotov6.InvokeActionRequest{
+				ActionType: tt.actionType,
+				Config:     p.dynamicValue(objectValue(actionType, values)),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var messages []string
+			for event := range stream.Events {
+				switch event := event.Type.(type) {
+				case tfprotov6.ProgressInvokeActionEventType:
+					messages = append(messages, event.Message)
+				case tfprotov6.CompletedInvokeActionEventType:
+					requireNoErrors(t, "InvokeAction", event.Diagnostics)
+				}
+			}
+			if len(messages) == 0 || messages[len(messages)-1] != tt.wantMessage {
+				t.Errorf("progress messages = %q, want the last to be %q", messages, tt.wantMessage)
+			}
+			sent := slices.ContainsFunc(sss.requests, func(request string) bool { return strings.HasSuffix(request, "/override") })
+			if sent == tt.dryRun {
+				t.Errorf("override sent to SSS = %t, want %t", sent, !tt.dryRun)
+			}
+		})
+	}
+}
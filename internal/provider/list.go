@@ -0,0 +1,127 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// scalableListConfigModel describes the list configuration shared by all scaling resources.
+type scalableListConfigModel struct {
+	Region types.String `tfsdk:"region"`
+	Tags   types.Map    `tfsdk:"tags"`
+}
+
+// scalableListConfigSchema returns the list configuration schema shared by all scaling resources.
+func scalableListConfigSchema(description string) listschema.Schema {
+	return listschema.Schema{
+		Description: description,
+		Attributes: map[string]listschema.Attribute{
+			"region": listschema.StringAttribute{
+				Description: "Only list scalables in this AWS region. E.g. eu-west-1. When omitted, every configured SSS endpoint is queried.",
+				Optional:    true,
+			},
+			"tags": listschema.MapAttribute{
+				Description: "Only list scalables registered with all of these tags.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// scalableListing describes how to list the registrations of one scalable type.
+type scalableListing[T any] struct {
+	scalableType client.ScalableType
+	name         string
+	list         func(*client.SssClient, context.Context) ([]T, error)
+	id           func(*T) string
+	region       func(*T) string
+	tags         func(*T) map[string]string
+	toModel      func(*T) any
+}
+
+// stream lists the registrations from SSS and streams them as list results.
+func (l scalableListing[T]) stream(ctx context.Context, c *client.SssClient, req list.ListRequest, stream *list.ListResultsStream) {
+	var config scalableListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	tags := map[string]string{}
+	if !config.Tags.IsNull() {
+		diags.Append(config.Tags.ElementsAs(ctx, &tags, false)...)
+		if diags.HasError() {
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+	}
+
+	clients := c.ForAllEndpoints()
+	if !config.Region.IsNull() {
+		clients = []*client.SssClient{c.ForRegion(config.Region.ValueString())}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		seen := map[string]bool{}
+		var count int64
+		for _, endpoint := range clients {
+			scalables, err := l.list(endpoint, ctx)
+			if err != nil {
+				var diags diag.Diagnostics
+				diags.AddError("Failed to list "+l.name, err.Error())
+				push(list.ListResult{Diagnostics: diags})
+				return
+			}
+
+			for i := range scalables {
+				scalable := &scalables[i]
+				id, region := l.id(scalable), l.region(scalable)
+				if !config.Region.IsNull() && region != config.Region.ValueString() {
+					continue
+				}
+				if !hasTags(l.tags(scalable), tags) {
+					continue
+				}
+				if seen[region+"/"+id] {
+					continue
+				}
+				seen[region+"/"+id] = true
+
+				result := req.NewListResult(ctx)
+				result.DisplayName = id
+				result.Diagnostics.Append(setScalableIdentity(ctx, result.Identity, l.scalableType, types.StringValue(id), types.StringValue(region), types.StringNull())...)
+				if req.IncludeResource {
+					result.Diagnostics.Append(result.Resource.Set(ctx, l.toModel(scalable))...)
+				}
+				if !push(result) {
+					return
+				}
+
+				count++
+				if req.Limit > 0 && count >= req.Limit {
+					return
+				}
+			}
+		}
+	}
+}
+
+// hasTags reports whether tags include every tag in filter.
+func hasTags(tags map[string]string, filter map[string]string) bool {
+	for key, value := range filter {
+		if tagValue, ok := tags[key]; !ok || tagValue != value {
+			return false
+		}
+	}
+	return true
+}
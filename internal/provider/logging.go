@@ -0,0 +1,19 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// withScalableLogFields adds the scalable type and ID to the fields of every
+// log entry written with ctx, including those of the client.
+func withScalableLogFields(ctx context.Context, scalableType client.ScalableType, scalableID types.String) context.Context {
+	ctx = tflog.SetField(ctx, "scalable_type", string(scalableType))
+	return tflog.SetField(ctx, "scalable_id", scalableID.ValueString())
+}
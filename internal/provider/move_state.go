@@ -0,0 +1,185 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// scalableMove describes how to move the state of other resource types to
+// one scaling resource type.
+type scalableMove[T any] struct {
+	scalableType client.ScalableType
+	// idAttribute is the resource attribute holding the scalable ID, e.g.
+	// service_id, and idField the field of the SSS registration holding it,
+	// e.g. name.
+	idAttribute string
+	idField     string
+	id          func(*T) string
+	region      func(*T) string
+	// toModel converts an SSS registration without the default tags, which
+	// are not known while moving, so that the next Read splits the inherited
+	// tags out of tags.
+	toModel func(*T) any
+}
+
+// movers returns the state movers of the resource type, accepting
+// restapi_object resources of the Mastercard/restapi provider that manage an
+// SSS registration, and resources of this provider of the same scalable type,
+// such as a resource type that has been renamed.
+func (m scalableMove[T]) movers() []resource.StateMover {
+	return []resource.StateMover{
+		{StateMover: m.moveRestapiObject},
+		{StateMover: m.moveSssResource},
+	}
+}
+
+// moveRestapiObject maps the JSON body of a restapi_object through the
+// To*ResourceModel converter of the resource. The body is taken from the
+// last response read from SSS, or else from the body sent to it.
+func (m scalableMove[T]) moveRestapiObject(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if !strings.HasSuffix(req.SourceProviderAddress, "/restapi") || req.SourceTypeName != "restapi_object" || req.SourceRawState == nil {
+		return
+	}
+
+	var source struct {
+		ID          string `json:"id"`
+		Path        string `json:"path"`
+		Data        string `json:"data"`
+		APIResponse string `json:"api_response"`
+	}
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Failed to move state", "Could not parse the restapi_object state: "+err.Error())
+		return
+	}
+	if !strings.HasSuffix(strings.TrimSuffix(source.Path, "/"), "/services/"+string(m.scalableType)) {
+		resp.Diagnostics.AddError(
+			"Failed to move state",
+			fmt.Sprintf("The restapi_object path %q does not manage SSS registrations of type %q.", source.Path, m.scalableType),
+		)
+		return
+	}
+
+	data := source.APIResponse
+	if data == "" {
+		data = source.Data
+	}
+	body := map[string]any{}
+	if err := json.Unmarshal([]byte(data), &body); err != nil {
+		resp.Diagnostics.AddError("Failed to move state", "Could not parse the restapi_object data as JSON: "+err.Error())
+		return
+	}
+	// The body sent to SSS does not hold the ID, which is part of the URL.
+	if id, ok := body[m.idField].(string); !ok || id == "" {
+		body[m.idField] = source.ID
+	}
+
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to move state", err.Error())
+		return
+	}
+	var scalable T
+	if err := json.Unmarshal(encoded, &scalable); err != nil {
+		resp.Diagnostics.AddError("Failed to move state", "The restapi_object data is not an SSS registration: "+err.Error())
+		return
+	}
+	if m.id(&scalable) == "" || m.region(&scalable) == "" {
+		resp.Diagnostics.AddError("Failed to move state", fmt.Sprintf("The restapi_object data must include the %s and region of the scalable.", m.idAttribute))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, m.toModel(&scalable))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.TargetIdentity, m.scalableType, types.StringValue(m.id(&scalable)), types.StringValue(m.region(&scalable)), types.StringNull())...)
+}
+
+// moveSssResource copies the attributes that the source and target resource
+// types have in common. The next Read refreshes the rest from SSS. The
+// scalable is taken from the source identity, or from the source state when
+// it was written without one, such as by a provider version without resource
+// identities.
+func (m scalableMove[T]) moveSssResource(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if !strings.HasSuffix(req.SourceProviderAddress, "/sss") || req.SourceRawState == nil {
+		return
+	}
+
+	var source map[string]any
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Failed to move state", "Could not parse the "+req.SourceTypeName+" state: "+err.Error())
+		return
+	}
+
+	var identity struct {
+		ScalableType string `json:"scalable_type"`
+		ScalableID   string `json:"scalable_id"`
+		Region       string `json:"region"`
+		Endpoint     string `json:"endpoint"`
+	}
+	if req.SourceIdentity != nil {
+		if err := json.Unmarshal(req.SourceIdentity.JSON, &identity); err != nil || identity.ScalableType != string(m.scalableType) {
+			return
+		}
+	} else {
+		// Without an identity, the source is taken to be of the same
+		// scalable type when it has every attribute the target requires.
+		for name, attribute := range resp.TargetState.Schema.GetAttributes() {
+			if attribute.IsRequired() && source[name] == nil {
+				return
+			}
+		}
+		identity.ScalableID, _ = source["scalable_id"].(string)
+		if identity.ScalableID == "" {
+			identity.ScalableID, _ = source[m.idAttribute].(string)
+		}
+		identity.Region, _ = source["region"].(string)
+		identity.Endpoint, _ = source["endpoint"].(string)
+		if identity.ScalableID == "" || identity.Region == "" {
+			return
+		}
+	}
+
+	targetType, ok := resp.TargetState.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	if !ok {
+		resp.Diagnostics.AddError("Failed to move state", "Unexpected target schema type. Please report this issue to the provider developers.")
+		return
+	}
+	target := map[string]any{}
+	for name := range targetType.AttributeTypes {
+		if value, ok := source[name]; ok {
+			target[name] = value
+		}
+	}
+	target[m.idAttribute] = identity.ScalableID
+	target["scalable_id"] = identity.ScalableID
+	target["region"] = identity.Region
+	target["endpoint"] = nil
+	if identity.Endpoint != "" {
+		target["endpoint"] = identity.Endpoint
+	}
+
+	encoded, err := json.Marshal(target)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to move state", err.Error())
+		return
+	}
+	raw, err := (&tfprotov6.DynamicValue{JSON: encoded}).Unmarshal(targetType)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to move state", "Could not convert the "+req.SourceTypeName+" state: "+err.Error())
+		return
+	}
+	resp.TargetState.Raw = raw
+	resp.Diagnostics.Append(setScalableIdentity(ctx, resp.TargetIdentity, m.scalableType, types.StringValue(identity.ScalableID), types.StringValue(identity.Region), optionalStringValue(identity.Endpoint))...)
+}
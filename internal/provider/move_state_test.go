@@ -0,0 +1,95 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMoveState(t *testing.T) {
+	const restapiState = `{"id":"service/cluster/app","path":"/api/v1/services/ecs","data":"{}",` +
+		`"api_response":"{\"name\":\"service/cluster/app\",\"region\":\"eu-west-1\",\"minLowCapacity\":1,\"minMediumCapacity\":2,\"minHighCapacity\":3,\"minExtremeCapacity\":4}"}`
+	const ecsState = `{"service_id":"service/cluster/app","region":"eu-west-1","min_tasks":{"low":1,"medium":2,"high":3,"extreme":4}}`
+	const ecsIdentity = `{"scalable_type":"ecs","scalable_id":"service/cluster/app","region":"eu-west-1"}`
+
+	tests := []struct {
+		name            string
+		providerAddress string
+		typeName        string
+		state           string
+		identity        string
+		wantErr         string
+	}{
+		{name: "restapi_object", providerAddress: "registry.terraform.io/mastercard/restapi", typeName: "restapi_object", state: restapiState},
+		{name: "restapi_object of another provider", providerAddress: "registry.terraform.io/example/other", typeName: "restapi_object", state: restapiState, wantErr: "Unable to Move Resource State"},
+		{name: "restapi_object of another type", providerAddress: "registry.terraform.io/mastercard/restapi", typeName: "restapi_object", state: strings.Replace(restapiState, "services/ecs", "services/dynamodb", 1), wantErr: "does not manage SSS registrations"},
+		{name: "renamed with identity", providerAddress: "registry.terraform.io/tv4/sss", typeName: "sss_ecs_service_scaling", state: ecsState, identity: ecsIdentity},
+		{name: "renamed without identity", providerAddress: "registry.terraform.io/tv4/sss", typeName: "sss_ecs_service_scaling", state: ecsState},
+		{name: "other scalable type with identity", providerAddress: "registry.terraform.io/tv4/sss", typeName: "sss_dynamo_table_scaling", state: ecsState, identity: strings.Replace(ecsIdentity, `"ecs"`, `"dynamo"`, 1), wantErr: "Unable to Move Resource State"},
+		{name: "other scalable type without identity", providerAddress: "registry.terraform.io/tv4/sss", typeName: "sss_eks_hpa_scaling", state: `{"service_id":"cluster/default/app","region":"eu-west-1","min_replicas":{"low":1,"medium":2,"high":3,"extreme":4}}`, wantErr: "Unable to Move Resource State"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sss := newFakeSSS(t)
+			p := newTestProvider(t, sss.host(), nil)
+			req := &tfprotov6.MoveResourceStateRequest{
+				SourceProviderAddress: tt.providerAddress,
+				SourceTypeName:        tt.typeName,
+				SourceState:           &tfprotov6.RawState{JSON: []byte(tt.state)},
+				TargetTypeName:        "sss_ecs_scaling",
+			}
+			if tt.identity != "" {
+				req.SourceIdentity = &tfprotov6.RawState{JSON: []byte(tt.identity)}
+			}
+			resp, err := p.server.MoveResourceState(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != "" {
+				for _, d := range resp.Diagnostics {
+					if d.Severity == tfprotov6.DiagnosticSeverityError && strings.Contains(d.Summary+": "+d.Detail, tt.wantErr) {
+						return
+					}
+				}
+				t.Fatalf("MoveResourceState() diagnostics = %v, want an error containing %q", resp.Diagnostics, tt.wantErr)
+			}
+			requireNoErrors(t, "MoveResourceState", resp.Diagnostics)
+
+			values := p.value("sss_ecs_scaling", resp.TargetState)
+			minTasks := p.resourceType("sss_ecs_scaling").AttributeTypes["min_tasks"].(tftypes.Object)
+			for name, want := range map[string]tftypes.Value{
+				"service_id": tftypes.NewValue(tftypes.String, "service/cluster/app"),
+				"region":     tftypes.NewValue(tftypes.String, "eu-west-1"),
+				"min_tasks": tftypes.NewValue(minTasks, map[string]tftypes.Value{
+					"low":     tftypes.NewValue(tftypes.Number, 1),
+					"medium":  tftypes.NewValue(tftypes.Number, 2),
+					"high":    tftypes.NewValue(tftypes.Number, 3),
+					"extreme": tftypes.NewValue(tftypes.Number, 4),
+				}),
+			} {
+				if !values[name].Equal(want) {
+					t.Errorf("%s = %s, want %s", name, values[name], want)
+				}
+			}
+
+			identityType := p.ids.IdentitySchemas["sss_ecs_scaling"].ValueType()
+			identity, err := resp.TargetIdentity.IdentityData.Unmarshal(identityType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var identityValues map[string]tftypes.Value
+			if err := identity.As(&identityValues); err != nil {
+				t.Fatal(err)
+			}
+			if !identityValues["scalable_id"].Equal(tftypes.NewValue(tftypes.String, "service/cluster/app")) || !identityValues["region"].Equal(tftypes.NewValue(tftypes.String, "eu-west-1")) {
+				t.Errorf("identity = %s", identity)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &parseEksServiceIDFunction{}
+
+// NewParseEksServiceIDFunction is a helper function to simplify the provider implementation.
+func NewParseEksServiceIDFunction() function.Function {
+	return &parseEksServiceIDFunction{}
+}
+
+// parseEksServiceIDFunction is the function implementation.
+type parseEksServiceIDFunction struct{}
+
+// Metadata returns the function name.
+func (f *parseEksServiceIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_eks_service_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *parseEksServiceIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Parse an sss_eks_hpa_scaling service ID.",
+		Description: "Splits a \"{namespace}/{name}@{cluster}\" service ID into an object with namespace, name and cluster attributes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "The service ID to parse, in the format \"{namespace}/{name}@{cluster}\".",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"namespace": types.StringType,
+				"name":      types.StringType,
+				"cluster":   types.StringType,
+			},
+		},
+	}
+}
+
+// Run parses the service ID and returns its components.
+func (f *parseEksServiceIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	parts, err := parseEksServiceID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, parts)
+}
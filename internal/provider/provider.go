@@ -5,11 +5,20 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
 	"terraform-provider-sss/internal/client"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -20,6 +29,16 @@ import (
 var _ provider.Provider = &SssProvider{}
 var _ provider.ProviderWithFunctions = &SssProvider{}
 var _ provider.ProviderWithEphemeralResources = &SssProvider{}
+var _ provider.ProviderWithListResources = &SssProvider{}
+var _ provider.ProviderWithActions = &SssProvider{}
+var _ provider.ProviderWithValidateConfig = &SssProvider{}
+
+// defaultProtocol is the protocol used when the provider configuration has
+// none.
+const defaultProtocol = "https"
+
+// healthCheckTimeout bounds the health check of each SSS host in Configure.
+const healthCheckTimeout = 30 * time.Second
 
 // SssProvider defines the provider implementation.
 type SssProvider struct {
@@ -31,10 +50,35 @@ type SssProvider struct {
 
 // SssProviderModel describes the provider data model.
 type SssProviderModel struct {
-	Host         types.String `tfsdk:"host"`
-	AuthUsername types.String `tfsdk:"auth_username"`
-	AuthPassword types.String `tfsdk:"auth_password"`
-	Protocol     types.String `tfsdk:"protocol"`
+	Host            types.String `tfsdk:"host"`
+	AuthUsername    types.String `tfsdk:"auth_username"`
+	AuthPassword    types.String `tfsdk:"auth_password"`
+	Protocol        types.String `tfsdk:"protocol"`
+	Endpoints       types.Map    `tfsdk:"endpoints"`
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+	Profile         types.String `tfsdk:"profile"`
+	DryRun          types.Bool   `tfsdk:"dry_run"`
+	SkipHealthCheck types.Bool   `tfsdk:"skip_health_check"`
+	CACertPEM       types.String `tfsdk:"ca_cert_pem"`
+	CACertFile      types.String `tfsdk:"ca_cert_file"`
+	ClientCert      types.String `tfsdk:"client_cert"`
+	ClientKey       types.String `tfsdk:"client_key"`
+	InsecureSkip    types.Bool   `tfsdk:"insecure_skip_verify"`
+	ProxyURL        types.String `tfsdk:"proxy_url"`
+	DefaultTags     *struct {
+		Tags types.Map `tfsdk:"tags"`
+	} `tfsdk:"default_tags"`
+	Guardrails *guardrailsModel `tfsdk:"guardrails"`
+}
+
+// resourceData is the provider data passed to resources.
+type resourceData struct {
+	client *client.SssClient
+	// defaultTags are merged into the tags of every resource. They are nil
+	// while not known.
+	defaultTags map[string]string
+	// guardrails bound the capacity of every resource.
+	guardrails guardrailsModel
 }
 
 func (p *SssProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -47,23 +91,109 @@ func (p *SssProvider) Schema(ctx context.Context, req provider.SchemaRequest, re
 		Description: "Interact with the TV4 Media AB Scheduled Scaling Service.",
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				MarkdownDescription: "The Scheduled Scaling Service API endpoint to connect to.",
+				MarkdownDescription: "The Scheduled Scaling Service API endpoint to connect to, a host name with an optional port and base path, such as `sss.example.com:8443/sss`.",
 				Required:            true,
 			},
+			"endpoints": schema.MapAttribute{
+				MarkdownDescription: "Regional Scheduled Scaling Service API endpoints, keyed by AWS region. Scalables in a region listed here are managed through that endpoint, all others through `host`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 			"protocol": schema.StringAttribute{
-				MarkdownDescription: "The protocol to use when connecting to the Scheduled Scaling Service API.",
+				MarkdownDescription: "The protocol to use when connecting to the Scheduled Scaling Service API, `http` or `https`. Defaults to `https`.",
 				Optional:            true,
 			},
 			"auth_username": schema.StringAttribute{
-				MarkdownDescription: "The basicauth username to authenticate with.",
-				Required:            true,
+				MarkdownDescription: "The basicauth username to authenticate with. Can also be set with the `SSS_AUTH_USERNAME` environment variable or a `credentials_file` profile.",
+				Optional:            true,
 			},
 			"auth_password": schema.StringAttribute{
-				MarkdownDescription: "The basicauth password to authenticate with.",
+				MarkdownDescription: "The basicauth password to authenticate with. Can also be set with the `SSS_AUTH_PASSWORD` environment variable or a `credentials_file` profile. Accepts ephemeral values, so the password can come from an ephemeral resource and is never stored in a plan.",
 				Sensitive:           true,
-				Required:            true,
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a credentials file with named profiles, either INI with one `[profile]` section per profile or a JSON object keyed by profile name, each holding `auth_username` and `auth_password`. Can also be set with the `SSS_CREDENTIALS_FILE` environment variable. Defaults to `~/.sss/credentials` if it exists.",
+				Optional:            true,
+			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "The `credentials_file` profile to use. Can also be set with the `SSS_PROFILE` environment variable. Defaults to `default`.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded certificates of CAs to trust in addition to the system CAs, for an SSS behind an internal CA. Conflicts with `ca_cert_file`.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with PEM encoded certificates of CAs to trust in addition to the system CAs. Conflicts with `ca_cert_pem`.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate to authenticate to SSS with mutual TLS. Requires `client_key`.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of `client_cert`.",
+				Sensitive:           true,
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verifying the TLS certificate of SSS. Only meant for testing, as it allows anyone on the network path to intercept the credentials. Defaults to `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of an `http`, `https` or `socks5` proxy to send requests to SSS through. Defaults to the proxy configured by the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"skip_health_check": schema.BoolAttribute{
+				MarkdownDescription: "Skip checking that SSS is reachable and accepts the credentials when the provider is configured, which otherwise fails early with a clear error. The check is also skipped while the host, protocol, endpoints, TLS or proxy settings are not known yet. Defaults to `false`.",
+				Optional:            true,
+			},
+			"dry_run": schema.BoolAttribute{
+				MarkdownDescription: "Log the requests that would create, update or delete registrations and overrides in SSS instead of sending them. `sss_api_token` cannot be opened in a dry run. Reads are still sent to SSS, so plans and applies can be checked against production data without changing it. The requests are logged at the `INFO` level, see `TF_LOG`. Defaults to `false`.",
+				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"default_tags": schema.SingleNestedBlock{
+				MarkdownDescription: "Tags to register with every scalable managed by the provider. Resource `tags` with the same key take precedence.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						MarkdownDescription: "The default tags.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+			"guardrails": guardrailsBlock(),
+		},
+	}
+}
+
+// ValidateConfig validates the protocol and the hosts.
+func (p *SssProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
+	var data SssProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Protocol.IsNull() && !data.Protocol.IsUnknown() && data.Protocol.ValueString() != "http" && data.Protocol.ValueString() != "https" {
+		resp.Diagnostics.AddAttributeError(path.Root("protocol"), "Invalid Protocol", fmt.Sprintf("protocol must be http or https, got %q.", data.Protocol.ValueString()))
+	}
+	if !data.Host.IsNull() && !data.Host.IsUnknown() {
+		if err := client.ValidateHost(data.Host.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("host"), "Invalid Host", err.Error())
+		}
+	}
+	for region, host := range data.Endpoints.Elements() {
+		host, ok := host.(types.String)
+		if !ok || host.IsNull() || host.IsUnknown() {
+			continue
+		}
+		if err := client.ValidateHost(host.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoints").AtMapKey(region), "Invalid Endpoint", err.Error())
+		}
 	}
 }
 
@@ -77,16 +207,196 @@ func (p *SssProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
-	// Configuration values are now available.
-	// if data.Endpoint.IsNull() { /* ... */ }
+	// Endpoints that are not known yet are left out, and so is the health
+	// check below.
+	endpoints := map[string]string{}
+	endpointsUnknown := data.Endpoints.IsUnknown()
+	if !data.Endpoints.IsNull() && !endpointsUnknown {
+		var endpointValues map[string]types.String
+		resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &endpointValues, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		for region, host := range endpointValues {
+			if host.IsUnknown() {
+				endpointsUnknown = true
+				continue
+			}
+			endpoints[region] = host.ValueString()
+		}
+	}
+
+	// Default tags that depend on values only known during apply are merged
+	// once the provider is configured again for the apply.
+	defaultTags := map[string]string{}
+	if data.DefaultTags != nil {
+		defaultTags, _ = knownStringMap(data.DefaultTags.Tags)
+	}
+
+	var guardrails guardrailsModel
+	if data.Guardrails != nil {
+		guardrails = *data.Guardrails
+		resp.Diagnostics.Append(guardrails.validate()...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	credentials, err := resolveCredentials(data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid SSS credentials", err.Error())
+		return
+	}
+
+	transportOptions, diags := resolveTransport(data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	protocol := data.Protocol.ValueString()
+	if protocol == "" {
+		protocol = defaultProtocol
+	}
 
-	// Example client configuration for data sources and resources
 	client := client.NewSssClient(
 		data.Host.ValueString(),
-		data.Protocol.ValueString(), data.AuthUsername.ValueString(), data.AuthPassword.ValueString(),
+		protocol, credentials.AuthUsername, credentials.AuthPassword,
+		append([]client.SssClientOption{
+			client.WithEndpoints(endpoints),
+			client.WithDryRun(data.DryRun.ValueBool()),
+		}, transportOptions...)...,
 	)
+	if client.DryRun() {
+		resp.Diagnostics.AddWarning("SSS dry run", "dry_run is set, so changes are logged and not sent to SSS.")
+	}
+
+	// SSS is only checked once the credentials and every setting of how to
+	// reach it are known.
+	connectionUnknown := data.Host.IsUnknown() || data.Protocol.IsUnknown() || endpointsUnknown || transportUnknown(data)
+	if !data.SkipHealthCheck.ValueBool() && !data.SkipHealthCheck.IsUnknown() && !connectionUnknown && credentials.AuthUsername != "" && credentials.AuthPassword != "" {
+		resp.Diagnostics.Append(checkHealth(ctx, client)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.ResourceData = &resourceData{client: client, defaultTags: defaultTags, guardrails: guardrails}
+	resp.ListResourceData = resp.ResourceData
+	resp.ActionData = client
+	resp.EphemeralResourceData = client
+}
+
+// resolveCredentials resolves each credential from, in order, the provider
+// configuration, the SSS_AUTH_USERNAME and SSS_AUTH_PASSWORD environment
+// variables and the credentials file profile.
+func resolveCredentials(data SssProviderModel) (*client.Credentials, error) {
+	credentials := &client.Credentials{
+		AuthUsername: valueOrEnv(data.AuthUsername, "SSS_AUTH_USERNAME"),
+		AuthPassword: valueOrEnv(data.AuthPassword, "SSS_AUTH_PASSWORD"),
+	}
+	// Credentials that depend on values only known during apply cannot be
+	// resolved yet.
+	if data.AuthUsername.IsUnknown() || data.AuthPassword.IsUnknown() || data.CredentialsFile.IsUnknown() || data.Profile.IsUnknown() {
+		return credentials, nil
+	}
+
+	err := client.FillCredentials(credentials, valueOrEnv(data.CredentialsFile, "SSS_CREDENTIALS_FILE"), valueOrEnv(data.Profile, "SSS_PROFILE"))
+	if err != nil {
+		return nil, err
+	}
+
+	if credentials.AuthUsername == "" || credentials.AuthPassword == "" {
+		return nil, fmt.Errorf("auth_username and auth_password must be configured, either in the provider configuration, with the SSS_AUTH_USERNAME and SSS_AUTH_PASSWORD environment variables or in a credentials_file profile")
+	}
+	return credentials, nil
+}
+
+// checkHealth checks that SSS is reachable and accepts the credentials on the
+// default host and every regional endpoint.
+func checkHealth(ctx context.Context, c *client.SssClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, endpointClient := range c.ForAllEndpoints() {
+		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+		err := endpointClient.CheckHealth(checkCtx)
+		cancel()
+		if errors.Is(err, client.ErrUnauthorized) {
+			diags.AddError("Invalid SSS credentials", err.Error()+". Check auth_username and auth_password, or the credentials_file profile.")
+		} else if err != nil {
+			diags.AddError("SSS health check failed", err.Error()+". Set skip_health_check = true to configure the provider without checking SSS.")
+		}
+	}
+	return diags
+}
+
+// resolveTransport returns the client options for the TLS and proxy settings
+// of the provider configuration.
+func resolveTransport(data SssProviderModel) ([]client.SssClientOption, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	// Settings that depend on values only known during apply cannot be
+	// resolved yet, and the client must not send requests without them.
+	if transportUnknown(data) {
+		return []client.SssClientOption{client.WithUnresolvedTransport()}, diags
+	}
+
+	var options []client.SssClientOption
+	settings := client.TLSSettings{
+		CACertPEM:          data.CACertPEM.ValueString(),
+		ClientCertPEM:      data.ClientCert.ValueString(),
+		ClientKeyPEM:       data.ClientKey.ValueString(),
+		InsecureSkipVerify: data.InsecureSkip.ValueBool(),
+	}
+	if !data.CACertFile.IsNull() {
+		if !data.CACertPEM.IsNull() {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Conflicting CA Certificates", "Only one of ca_cert_pem and ca_cert_file can be configured.")
+			return nil, diags
+		}
+		pem, err := client.ReadCACertFile(data.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Invalid CA Certificate", err.Error())
+			return nil, diags
+		}
+		settings.CACertPEM = pem
+	}
+	tlsConfig, err := settings.Config()
+	if err != nil {
+		diags.AddError("Invalid TLS Settings", err.Error())
+		return nil, diags
+	}
+	if tlsConfig != nil {
+		options = append(options, client.WithTLSConfig(tlsConfig))
+	}
+	if settings.InsecureSkipVerify {
+		diags.AddAttributeWarning(path.Root("insecure_skip_verify"), "TLS Verification Disabled", "insecure_skip_verify is set, so the certificate of SSS is not verified and the credentials sent to it can be intercepted.")
+	}
+
+	if !data.ProxyURL.IsNull() {
+		proxyURL, err := client.ParseProxyURL(data.ProxyURL.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("proxy_url"), "Invalid Proxy URL", err.Error())
+			return nil, diags
+		}
+		options = append(options, client.WithProxy(proxyURL))
+	}
+	return options, diags
+}
+
+// transportUnknown reports whether any TLS or proxy setting is unknown.
+func transportUnknown(data SssProviderModel) bool {
+	for _, value := range []attr.Value{data.CACertPEM, data.CACertFile, data.ClientCert, data.ClientKey, data.InsecureSkip, data.ProxyURL} {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+func valueOrEnv(value types.String, key string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv(key)
 }
 
 func (p *SssProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -97,8 +407,25 @@ func (p *SssProvider) Resources(ctx context.Context) []func() resource.Resource
 	}
 }
 
+func (p *SssProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewEcsScalingListResource,
+		NewDynamoTableScalingListResource,
+		NewEksHpaScalingListResource,
+	}
+}
+
+func (p *SssProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewSetLevelAction,
+		NewClearOverrideAction,
+	}
+}
+
 func (p *SssProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
-	return []func() ephemeral.EphemeralResource{}
+	return []func() ephemeral.EphemeralResource{
+		NewApiTokenEphemeralResource,
+	}
 }
 
 func (p *SssProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
@@ -106,7 +433,15 @@ func (p *SssProvider) DataSources(ctx context.Context) []func() datasource.DataS
 }
 
 func (p *SssProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewEksServiceIDFunction,
+		NewParseEksServiceIDFunction,
+		NewEcsServiceIDFromArnFunction,
+		NewParseEcsServiceArnFunction,
+		NewDynamoTableIDFromArnFunction,
+		NewParseDynamoTableArnFunction,
+		NewScaleLevelsFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
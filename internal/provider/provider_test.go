@@ -0,0 +1,317 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// fakeSSS is an in-memory SSS API that stores registrations as sent.
+type fakeSSS struct {
+	t        *testing.T
+	server   *httptest.Server
+	mu       sync.Mutex
+	scalable map[string]map[string]any
+	requests []string
+}
+
+func newFakeSSS(t *testing.T) *fakeSSS {
+	t.Helper()
+	f := &fakeSSS{t: t, scalable: map[string]map[string]any{}}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.server.Close)
+	return f
+}
+
+// host returns the host the provider is configured with.
+func (f *fakeSSS) host() string {
+	return strings.TrimPrefix(f.server.URL, "http://")
+}
+
+func (f *fakeSSS) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	apiPath, _ := url.PathUnescape(r.URL.EscapedPath())
+	apiPath, _ = url.PathUnescape(apiPath)
+	if apiPath == "/api/v1/health" {
+		return
+	}
+	if strings.HasSuffix(apiPath, "/status") {
+		if _, ok := f.scalable[strings.TrimSuffix(apiPath, "/status")]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = io.WriteString(w, `{"currentLevel":"low","effectiveMin":1,"lastApplyStatus":"applied","lastAppliedAt":"2026-10-19T08:00:00Z"}`)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		registration, ok := f.scalable[apiPath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(registration)
+	case http.MethodPost, http.MethodPut:
+		var registration map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&registration); err != nil {
+			f.t.Errorf("invalid request body: %v", err)
+		}
+		name := strings.TrimPrefix(apiPath, "/api/v1/services/")
+		_, name, _ = strings.Cut(name, "/")
+		registration["name"] = name
+		registration["tableName"] = name
+		f.scalable[apiPath] = registration
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+	case http.MethodDelete:
+		delete(f.scalable, apiPath)
+	}
+}
+
+// registration returns the registration stored at apiPath.
+func (f *fakeSSS) registration(apiPath string) map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.scalable[apiPath]
+}
+
+// update changes the registration stored at apiPath, the way SSS does on its
+// own.
+func (f *fakeSSS) update(apiPath string, change func(registration map[string]any)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	registration, ok := f.scalable[apiPath]
+	if !ok {
+		f.t.Fatalf("no registration at %s", apiPath)
+	}
+	change(registration)
+}
+
+// testProvider is a provider server configured against a fake SSS.
+type testProvider struct {
+	t      *testing.T
+	server tfprotov6.ProviderServer
+	schema *tfprotov6.GetProviderSchemaResponse
+	ids    *tfprotov6.GetResourceIdentitySchemasResponse
+}
+
+// newTestProvider configures the provider with host and the given provider
+// attributes.
+func newTestProvider(t *testing.T, host string, config map[string]tftypes.Value) *testProvider {
+	t.Helper()
+	ctx := context.Background()
+	server := providerserver.NewProtocol6(New("test")())()
+	schema, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids, err := server.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &testProvider{t: t, server: server, schema: schema, ids: ids}
+
+	values := map[string]tftypes.Value{
+		"host":          tftypes.NewValue(tftypes.String, host),
+		"protocol":      tftypes.NewValue(tftypes.String, "http"),
+		"auth_username": tftypes.NewValue(tftypes.String, "user"),
+		"auth_password": tftypes.NewValue(tftypes.String, "secret"),
+	}
+	for name, value := range config {
+		values[name] = value
+	}
+	providerType := schema.Provider.ValueType().(tftypes.Object)
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: p.dynamicValue(objectValue(providerType, values))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	requireNoErrors(t, "ConfigureProvider", resp.Diagnostics)
+	return p
+}
+
+// resourceType returns the object type of a resource.
+func (p *testProvider) resourceType(typeName string) tftypes.Object {
+	return p.schema.ResourceSchemas[typeName].ValueType().(tftypes.Object)
+}
+
+// config returns the configuration of a resource, with null values for the
+// attributes not given.
+func (p *testProvider) config(typeName string, values map[string]tftypes.Value) tftypes.Value {
+	return objectValue(p.resourceType(typeName), values)
+}
+
+func (p *testProvider) dynamicValue(value tftypes.Value) *tfprotov6.DynamicValue {
+	p.t.Helper()
+	dv, err := tfprotov6.NewDynamicValue(value.Type(), value)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return &dv
+}
+
+// testResourceState is the state of a resource after an apply.
+type testResourceState struct {
+	state    *tfprotov6.DynamicValue
+	private  []byte
+	identity *tfprotov6.ResourceIdentityData
+}
+
+// value returns the state as a value of the resource type.
+func (p *testProvider) value(typeName string, dv *tfprotov6.DynamicValue) map[string]tftypes.Value {
+	p.t.Helper()
+	value, err := dv.Unmarshal(p.resourceType(typeName))
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	var values map[string]tftypes.Value
+	if err := value.As(&values); err != nil {
+		p.t.Fatal(err)
+	}
+	return values
+}
+
+// plan plans the change from prior, which is nil to create the resource, to
+// config.
+func (p *testProvider) plan(typeName string, prior *testResourceState, config tftypes.Value) *tfprotov6.PlanResourceChangeResponse {
+	p.t.Helper()
+	req := &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       p.dynamicValue(tftypes.NewValue(p.resourceType(typeName), nil)),
+		ProposedNewState: p.dynamicValue(config),
+		Config:           p.dynamicValue(config),
+	}
+	if prior != nil {
+		req.PriorState = prior.state
+		req.PriorPrivate = prior.private
+		req.PriorIdentity = prior.identity
+		req.ProposedNewState = p.dynamicValue(p.proposedNewState(typeName, prior.state, config))
+	}
+	resp, err := p.server.PlanResourceChange(context.Background(), req)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return resp
+}
+
+// apply applies a plan of the change from prior to config.
+func (p *testProvider) apply(typeName string, prior *testResourceState, config tftypes.Value, plan *tfprotov6.PlanResourceChangeResponse) (*testResourceState, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	req := &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:        typeName,
+		PriorState:      p.dynamicValue(tftypes.NewValue(p.resourceType(typeName), nil)),
+		PlannedState:    plan.PlannedState,
+		Config:          p.dynamicValue(config),
+		PlannedPrivate:  plan.PlannedPrivate,
+		PlannedIdentity: plan.PlannedIdentity,
+	}
+	if prior != nil {
+		req.PriorState = prior.state
+	}
+	resp, err := p.server.ApplyResourceChange(context.Background(), req)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return &testResourceState{state: resp.NewState, private: resp.Private, identity: resp.NewIdentity}, resp.Diagnostics
+}
+
+// create plans and applies the creation of a resource.
+func (p *testProvider) create(typeName string, config tftypes.Value) *testResourceState {
+	p.t.Helper()
+	plan := p.plan(typeName, nil, config)
+	requireNoErrors(p.t, "PlanResourceChange", plan.Diagnostics)
+	state, diags := p.apply(typeName, nil, config, plan)
+	requireNoErrors(p.t, "ApplyResourceChange", diags)
+	return state
+}
+
+// read refreshes a resource.
+func (p *testProvider) read(typeName string, current *testResourceState) (*testResourceState, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	resp, err := p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:        typeName,
+		CurrentState:    current.state,
+		Private:         current.private,
+		CurrentIdentity: current.identity,
+	})
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return &testResourceState{state: resp.NewState, private: resp.Private, identity: resp.NewIdentity}, resp.Diagnostics
+}
+
+// proposedNewState merges the prior state into config the way Terraform does
+// for computed attributes that are not configured.
+func (p *testProvider) proposedNewState(typeName string, prior *tfprotov6.DynamicValue, config tftypes.Value) tftypes.Value {
+	p.t.Helper()
+	priorValues := p.value(typeName, prior)
+	var configValues map[string]tftypes.Value
+	if err := config.As(&configValues); err != nil {
+		p.t.Fatal(err)
+	}
+	for _, attribute := range p.schema.ResourceSchemas[typeName].Block.Attributes {
+		if attribute.Computed && configValues[attribute.Name].IsNull() {
+			configValues[attribute.Name] = priorValues[attribute.Name]
+		}
+	}
+	return tftypes.NewValue(config.Type(), configValues)
+}
+
+// objectValue returns an object of typ with the given attribute values, and
+// null values for the others.
+func objectValue(typ tftypes.Object, values map[string]tftypes.Value) tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range typ.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	return tftypes.NewValue(typ, attributes)
+}
+
+// ecsConfig returns a configuration of sss_ecs_scaling, overridden by values.
+func ecsConfig(p *testProvider, values map[string]tftypes.Value) tftypes.Value {
+	minTasks := p.resourceType("sss_ecs_scaling").AttributeTypes["min_tasks"].(tftypes.Object)
+	config := map[string]tftypes.Value{
+		"service_id": tftypes.NewValue(tftypes.String, "service/cluster/app"),
+		"region":     tftypes.NewValue(tftypes.String, "eu-west-1"),
+		"min_tasks": tftypes.NewValue(minTasks, map[string]tftypes.Value{
+			"low":     tftypes.NewValue(tftypes.Number, 1),
+			"medium":  tftypes.NewValue(tftypes.Number, 2),
+			"high":    tftypes.NewValue(tftypes.Number, 3),
+			"extreme": tftypes.NewValue(tftypes.Number, 4),
+		}),
+	}
+	for name, value := range values {
+		config[name] = value
+	}
+	return p.config("sss_ecs_scaling", config)
+}
+
+func requireNoErrors(t *testing.T, operation string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("%s returned error: %s: %s", operation, d.Summary, d.Detail)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// planRegionReplacement marks region as requiring replacement when the planned
+// region is served by a different SSS endpoint than the current one, since the
+// registration cannot be updated in place across SSS instances. A scalable
+// with an endpoint stays on that host whatever its region, and a changed
+// endpoint forces replacement by itself.
+func planRegionReplacement(ctx context.Context, c *client.SssClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if c == nil || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var stateRegion, planRegion, planEndpoint types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("region"), &stateRegion)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("region"), &planRegion)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("endpoint"), &planEndpoint)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planRegion.Equal(stateRegion) || planEndpoint.IsUnknown() || planEndpoint.ValueString() != "" {
+		return
+	}
+
+	if planRegion.IsUnknown() {
+		if c.HasEndpoints() {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("region"))
+		}
+		return
+	}
+
+	if c.HostForRegion(stateRegion.ValueString()) != c.HostForRegion(planRegion.ValueString()) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("region"))
+	}
+}
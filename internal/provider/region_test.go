@@ -0,0 +1,59 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPlanRegionChange(t *testing.T) {
+	tests := []struct {
+		name        string
+		endpoints   map[string]tftypes.Value
+		wantReplace bool
+	}{
+		{name: "same host", wantReplace: false},
+		{name: "other endpoint", endpoints: map[string]tftypes.Value{"eu-north-1": tftypes.NewValue(tftypes.String, "sss.eu-north-1.example.com")}, wantReplace: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sss := newFakeSSS(t)
+			config := map[string]tftypes.Value{"skip_health_check": tftypes.NewValue(tftypes.Bool, true)}
+			if tt.endpoints != nil {
+				config["endpoints"] = tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, tt.endpoints)
+			}
+			p := newTestProvider(t, sss.host(), config)
+			state := p.create("sss_ecs_scaling", ecsConfig(p, nil))
+
+			moved := ecsConfig(p, map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "eu-north-1")})
+			plan := p.plan("sss_ecs_scaling", state, moved)
+			requireNoErrors(t, "PlanResourceChange", plan.Diagnostics)
+			if replace := len(plan.RequiresReplace) > 0; replace != tt.wantReplace {
+				t.Fatalf("RequiresReplace = %v, want replacement %v", plan.RequiresReplace, tt.wantReplace)
+			}
+			if tt.wantReplace {
+				return
+			}
+
+			updated, diags := p.apply("sss_ecs_scaling", state, moved, plan)
+			requireNoErrors(t, "ApplyResourceChange", diags)
+			identity, err := updated.identity.IdentityData.Unmarshal(p.ids.IdentitySchemas["sss_ecs_scaling"].ValueType())
+			if err != nil {
+				t.Fatal(err)
+			}
+			var attributes map[string]tftypes.Value
+			if err := identity.As(&attributes); err != nil {
+				t.Fatal(err)
+			}
+			if region := attributes["region"]; !region.Equal(tftypes.NewValue(tftypes.String, "eu-north-1")) {
+				t.Fatalf("identity region = %s, want eu-north-1", region)
+			}
+
+			_, diags = p.read("sss_ecs_scaling", updated)
+			requireNoErrors(t, "ReadResource", diags)
+		})
+	}
+}
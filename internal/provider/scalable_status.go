@@ -0,0 +1,80 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"terraform-provider-sss/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// scalableStatusModel holds the computed status attributes shared by all
+// scaling resources.
+type scalableStatusModel struct {
+	CurrentLevel    types.String `tfsdk:"current_level"`
+	LastAppliedAt   types.String `tfsdk:"last_applied_at"`
+	LastApplyStatus types.String `tfsdk:"last_apply_status"`
+}
+
+// scalableStatusAttributes returns the computed status attributes shared by
+// all scaling resources.
+func scalableStatusAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"current_level": schema.StringAttribute{
+			Description: "The schedule level SSS currently applies to the scalable, one of low, medium, high or extreme.",
+			Computed:    true,
+		},
+		"last_applied_at": schema.StringAttribute{
+			Description: "When SSS last applied capacity to the scalable, in RFC 3339 format.",
+			Computed:    true,
+		},
+		"last_apply_status": schema.StringAttribute{
+			Description: "The outcome of the last time SSS applied capacity to the scalable, e.g. applied, pending or failed.",
+			Computed:    true,
+		},
+	}
+}
+
+// newScalableStatusModel converts an SSS status to the status attributes. A
+// nil status, of a scalable SSS has not applied yet, gives null attributes.
+func newScalableStatusModel(status *client.ScalableStatusResponse) scalableStatusModel {
+	if status == nil {
+		return scalableStatusModel{
+			CurrentLevel:    types.StringNull(),
+			LastAppliedAt:   types.StringNull(),
+			LastApplyStatus: types.StringNull(),
+		}
+	}
+
+	m := scalableStatusModel{
+		CurrentLevel:    types.StringValue(status.CurrentLevel),
+		LastAppliedAt:   types.StringNull(),
+		LastApplyStatus: types.StringValue(status.LastApplyStatus),
+	}
+	if status.LastAppliedAt != nil {
+		m.LastAppliedAt = types.StringValue(status.LastAppliedAt.UTC().Format(time.RFC3339))
+	}
+	return m
+}
+
+// getScalableStatus fetches the status of a scalable. The status only
+// supplements the registration, so failing to fetch it is a warning, and a
+// scalable without status yields nil.
+func getScalableStatus(ctx context.Context, c *client.SssClient, scalableType client.ScalableType, scalableID string) (*client.ScalableStatusResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	status, err := c.GetScalableStatus(ctx, scalableType, scalableID)
+	if errors.Is(err, client.ErrNotFound) {
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddWarning("Failed to read scaling status", "The current level and applied capacity could not be read from SSS: "+err.Error())
+		return nil, diags
+	}
+	return status, diags
+}
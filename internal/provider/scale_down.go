@@ -0,0 +1,96 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-sss/internal/client"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// eventScaleLevels are the levels SSS schedules for events, during which
+// lowering the minimum capacity is refused unless explicitly allowed.
+var eventScaleLevels = map[string]bool{"high": true, "extreme": true}
+
+// allowScaleDownAttribute returns the allow_scale_down_during_event attribute
+// shared by all scaling resources.
+func allowScaleDownAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "Whether to apply a plan that lowers the minimum capacity of the level SSS currently applies while it is high or extreme. " +
+			"When false, such plans fail with an error, otherwise they only warn. Defaults to false.",
+		Optional: true,
+	}
+}
+
+// modifyPlanScaleDown warns when an update lowers a minimum capacity of the
+// level SSS currently applies to the scalable, and refuses it during high and
+// extreme levels unless allow_scale_down_during_event is true. minimumPaths
+// returns the paths of the minimum capacities of a level. SSS is only asked
+// for the current level when the plan lowers a minimum of any level.
+func modifyPlanScaleDown(ctx context.Context, c *client.SssClient, scalableType client.ScalableType, idPath path.Path, minimumPaths func(level string) []path.Path, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only updates lower the capacity SSS applies, and SSS cannot be asked
+	// before the TLS and proxy settings are known.
+	if c == nil || !c.TransportResolved() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// The reductions of every level are collected once, so that diagnostics
+	// of reading the attributes are not repeated.
+	reductions := map[string][]string{}
+	for _, level := range scaleLevelNames {
+		for _, minimumPath := range minimumPaths(level) {
+			var prior, planned types.Int64
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, minimumPath, &prior)...)
+			resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, minimumPath, &planned)...)
+			if prior.IsNull() || prior.IsUnknown() || planned.IsNull() || planned.IsUnknown() {
+				continue
+			}
+			if planned.ValueInt64() < prior.ValueInt64() {
+				reductions[level] = append(reductions[level], fmt.Sprintf("%s from %d to %d", minimumPath, prior.ValueInt64(), planned.ValueInt64()))
+			}
+		}
+	}
+	if len(reductions) == 0 || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var scalableID, region, endpoint types.String
+	var allowScaleDown types.Bool
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, idPath, &scalableID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("region"), &region)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("endpoint"), &endpoint)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("allow_scale_down_during_event"), &allowScaleDown)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withScalableLogFields(ctx, scalableType, scalableID)
+	status, diags := getScalableStatus(ctx, scalableClient(c, region, endpoint), scalableType, scalableID.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if status == nil {
+		return
+	}
+	// An empty or unexpected level names no capacity of the scalable.
+	if !slices.Contains(scaleLevelNames, status.CurrentLevel) || len(reductions[status.CurrentLevel]) == 0 {
+		return
+	}
+
+	detail := fmt.Sprintf("SSS currently applies the %s level to %s/%s, and this plan lowers %s, which takes effect as soon as it is applied.",
+		status.CurrentLevel, scalableType, scalableID.ValueString(), strings.Join(reductions[status.CurrentLevel], ", "))
+	if eventScaleLevels[status.CurrentLevel] && !allowScaleDown.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Scale-down during an active "+status.CurrentLevel+" level",
+			detail+" Apply it after the event, or set allow_scale_down_during_event = true to apply it anyway.",
+		)
+		return
+	}
+	resp.Diagnostics.AddWarning("Scale-down of the active "+status.CurrentLevel+" level", detail)
+}
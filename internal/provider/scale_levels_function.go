@@ -0,0 +1,323 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &scaleLevelsFunction{}
+
+// scaleLevelNames lists the SSS schedule levels in ascending order.
+var scaleLevelNames = []string{"low", "medium", "high", "extreme"}
+
+// scaleLevelsProfiles are the named multiplier profiles accepted by
+// scale_levels, indexed in the same order as scaleLevelNames.
+var scaleLevelsProfiles = map[string][4]float64{
+	"flat":       {1, 1, 1, 1},
+	"standard":   {1, 1.5, 2, 3},
+	"aggressive": {1, 2, 4, 8},
+}
+
+// scaleLevelsResult is the object returned by scale_levels.
+type scaleLevelsResult struct {
+	Low     int64 `tfsdk:"low"`
+	Medium  int64 `tfsdk:"medium"`
+	High    int64 `tfsdk:"high"`
+	Extreme int64 `tfsdk:"extreme"`
+}
+
+// scaleLevelsOptions holds the optional rounding and clamping settings.
+type scaleLevelsOptions struct {
+	Rounding string
+	Min      *int64
+	Max      *int64
+}
+
+// NewScaleLevelsFunction is a helper function to simplify the provider implementation.
+func NewScaleLevelsFunction() function.Function {
+	return &scaleLevelsFunction{}
+}
+
+// scaleLevelsFunction is the function implementation.
+type scaleLevelsFunction struct{}
+
+// Metadata returns the function name.
+func (f *scaleLevelsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "scale_levels"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *scaleLevelsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Compute a level map from a base capacity.",
+		MarkdownDescription: "Multiplies a base capacity by per-level multipliers and returns an object with `low`, `medium`, `high` and `extreme` " +
+			"attributes, suitable for `min_tasks` or `min_replicas`.\n\n" +
+			"The multipliers are either an object with a number for each level, or the name of a profile: " +
+			"`flat` (1, 1, 1, 1), `standard` (1, 1.5, 2, 3) or `aggressive` (1, 2, 4, 8).\n\n" +
+			"The options object, which may be `null`, accepts `rounding` (`ceil`, `floor` or `round`, defaults to `ceil`) " +
+			"and whole number `min`/`max` bounds that every level is clamped to after rounding.",
+		Parameters: []function.Parameter{
+			function.Float64Parameter{
+				Name:        "base",
+				Description: "The base capacity, usually the capacity needed at the low level.",
+			},
+			function.DynamicParameter{
+				Name:        "multipliers",
+				Description: "An object with low, medium, high and extreme multipliers, or the name of a profile.",
+			},
+			function.DynamicParameter{
+				Name:           "options",
+				Description:    "An object with optional rounding, min and max attributes, or null.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"low":     types.Int64Type,
+				"medium":  types.Int64Type,
+				"high":    types.Int64Type,
+				"extreme": types.Int64Type,
+			},
+		},
+	}
+}
+
+// Run computes the level map.
+func (f *scaleLevelsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var base float64
+	var multipliersArg, optionsArg types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &base, &multipliersArg, &optionsArg)
+	if resp.Error != nil {
+		return
+	}
+
+	if base < 0 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("base must not be negative, got %v", base))
+		return
+	}
+
+	multipliers, err := scaleLevelsMultipliers(multipliersArg)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+
+	options, err := scaleLevelsParseOptions(optionsArg)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(2, err.Error())
+		return
+	}
+
+	var levels [4]int64
+	for i, multiplier := range multipliers {
+		levels[i], err = scaleLevel(base, multiplier, options)
+		if err != nil {
+			resp.Error = function.NewFuncError(fmt.Sprintf("%s level: %s", scaleLevelNames[i], err))
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, scaleLevelsResult{
+		Low:     levels[0],
+		Medium:  levels[1],
+		High:    levels[2],
+		Extreme: levels[3],
+	})
+}
+
+// scaleLevel multiplies base by multiplier, rounds the product and clamps it
+// to the bounds. The numbers are multiplied as the decimals they are written
+// as, so 50 * 1.1 is exactly 55 rather than a float just above it.
+func scaleLevel(base, multiplier float64, options scaleLevelsOptions) (int64, error) {
+	product := new(big.Rat).Mul(decimalRat(base), decimalRat(multiplier))
+	num, den := product.Num(), product.Denom()
+
+	// The product is never negative, so integer division truncates towards
+	// the floor.
+	value := new(big.Int)
+	switch options.Rounding {
+	case "floor":
+		value.Quo(num, den)
+	case "round":
+		value.Quo(new(big.Int).Add(new(big.Int).Lsh(num, 1), den), new(big.Int).Lsh(den, 1))
+	default:
+		value.Quo(new(big.Int).Add(num, new(big.Int).Sub(den, big.NewInt(1))), den)
+	}
+
+	if options.Min != nil && value.Cmp(big.NewInt(*options.Min)) < 0 {
+		value.SetInt64(*options.Min)
+	}
+	if options.Max != nil && value.Cmp(big.NewInt(*options.Max)) > 0 {
+		value.SetInt64(*options.Max)
+	}
+	if !value.IsInt64() {
+		return 0, fmt.Errorf("%v * %v does not fit in a 64-bit integer", base, multiplier)
+	}
+	return value.Int64(), nil
+}
+
+// decimalRat returns the shortest decimal that rounds to f as an exact
+// rational number. f must be finite.
+func decimalRat(f float64) *big.Rat {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return r
+}
+
+// scaleLevelsMultipliers resolves the multipliers argument from either a
+// profile name or an object/map of numbers.
+func scaleLevelsMultipliers(arg types.Dynamic) ([4]float64, error) {
+	if arg.IsUnderlyingValueNull() {
+		return [4]float64{}, fmt.Errorf("multipliers must not be null")
+	}
+
+	if profile, ok := arg.UnderlyingValue().(types.String); ok {
+		multipliers, found := scaleLevelsProfiles[profile.ValueString()]
+		if !found {
+			return [4]float64{}, fmt.Errorf("unknown profile %q, expected one of: %s", profile.ValueString(), strings.Join(scaleLevelsProfileNames(), ", "))
+		}
+		return multipliers, nil
+	}
+
+	values, err := dynamicAttributes(arg, "multipliers")
+	if err != nil {
+		return [4]float64{}, err
+	}
+
+	var multipliers [4]float64
+	for name := range values {
+		if !slices.Contains(scaleLevelNames, name) {
+			return [4]float64{}, fmt.Errorf("unexpected multiplier %q, expected only: %s", name, strings.Join(scaleLevelNames, ", "))
+		}
+	}
+	for i, name := range scaleLevelNames {
+		value, found := values[name]
+		if !found {
+			return [4]float64{}, fmt.Errorf("missing multiplier %q", name)
+		}
+		multiplier, err := numberValue(value)
+		if err != nil {
+			return [4]float64{}, fmt.Errorf("multiplier %q: %w", name, err)
+		}
+		if multiplier < 0 {
+			return [4]float64{}, fmt.Errorf("multiplier %q must not be negative, got %v", name, multiplier)
+		}
+		multipliers[i] = multiplier
+	}
+	return multipliers, nil
+}
+
+// scaleLevelsParseOptions decodes the optional options argument.
+func scaleLevelsParseOptions(arg types.Dynamic) (scaleLevelsOptions, error) {
+	options := scaleLevelsOptions{Rounding: "ceil"}
+	if arg.IsNull() || arg.IsUnderlyingValueNull() {
+		return options, nil
+	}
+
+	values, err := dynamicAttributes(arg, "options")
+	if err != nil {
+		return options, err
+	}
+
+	for name, value := range values {
+		switch name {
+		case "rounding":
+			rounding, ok := value.(types.String)
+			if !ok {
+				return options, fmt.Errorf("rounding must be a string")
+			}
+			if !rounding.IsNull() {
+				options.Rounding = rounding.ValueString()
+			}
+			if !slices.Contains([]string{"ceil", "floor", "round"}, options.Rounding) {
+				return options, fmt.Errorf("rounding must be one of: ceil, floor, round, got %q", options.Rounding)
+			}
+		case "min", "max":
+			if value.IsNull() {
+				continue
+			}
+			bound, err := numberValue(value)
+			if err != nil {
+				return options, fmt.Errorf("%s: %w", name, err)
+			}
+			// Levels are whole numbers, so a fractional bound would be
+			// truncated after clamping.
+			if bound != math.Trunc(bound) {
+				return options, fmt.Errorf("%s must be a whole number, got %v", name, bound)
+			}
+			if bound < math.MinInt64 || bound >= math.MaxInt64 {
+				return options, fmt.Errorf("%s must fit in a 64-bit integer, got %v", name, bound)
+			}
+			level := int64(bound)
+			if name == "min" {
+				options.Min = &level
+			} else {
+				options.Max = &level
+			}
+		default:
+			return options, fmt.Errorf("unexpected option %q, expected only: rounding, min, max", name)
+		}
+	}
+
+	if options.Min != nil && options.Max != nil && *options.Min > *options.Max {
+		return options, fmt.Errorf("min (%d) must not be greater than max (%d)", *options.Min, *options.Max)
+	}
+	return options, nil
+}
+
+// dynamicAttributes returns the attributes of an object or the elements of a
+// map held in a dynamic value.
+func dynamicAttributes(arg types.Dynamic, name string) (map[string]attr.Value, error) {
+	switch value := arg.UnderlyingValue().(type) {
+	case types.Object:
+		return value.Attributes(), nil
+	case types.Map:
+		return value.Elements(), nil
+	default:
+		return nil, fmt.Errorf("%s must be an object, got %s", name, arg.UnderlyingValue().Type(context.Background()))
+	}
+}
+
+// numberValue converts a known numeric attribute value to a float64.
+func numberValue(value attr.Value) (float64, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return 0, fmt.Errorf("must be a known number")
+	}
+	switch v := value.(type) {
+	case types.Number:
+		f, _ := v.ValueBigFloat().Float64()
+		if math.IsInf(f, 0) {
+			return 0, fmt.Errorf("must fit in a 64-bit float, got %s", v.ValueBigFloat())
+		}
+		return f, nil
+	case types.Int64:
+		return float64(v.ValueInt64()), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	default:
+		return 0, fmt.Errorf("must be a number")
+	}
+}
+
+func scaleLevelsProfileNames() []string {
+	names := make([]string, 0, len(scaleLevelsProfiles))
+	for name := range scaleLevelsProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,99 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestScaleLevelsFunctionRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		base        float64
+		multipliers types.Dynamic
+		options     types.Dynamic
+		want        string
+		wantErr     string
+	}{
+		{name: "standard profile", base: 3, multipliers: scaleLevelsString("standard"), options: types.DynamicNull(), want: `{"extreme":9,"high":6,"low":3,"medium":5}`},
+		{name: "exact ceil", base: 50, multipliers: scaleLevelsMultipliersValue(1, 1.1, 2.2, 3.3), options: types.DynamicNull(), want: `{"extreme":165,"high":110,"low":50,"medium":55}`},
+		{name: "exact ceil of decimal base", base: 25, multipliers: scaleLevelsMultipliersValue(1, 2.2, 1.1, 3), options: types.DynamicNull(), want: `{"extreme":75,"high":28,"low":25,"medium":55}`},
+		{name: "exact floor", base: 100, multipliers: scaleLevelsMultipliersValue(1, 1.15, 1.3, 2.01), options: scaleLevelsOptionsValue("floor", nil, nil), want: `{"extreme":201,"high":130,"low":100,"medium":115}`},
+		{name: "round half up", base: 5, multipliers: scaleLevelsMultipliersValue(1, 1.1, 1.3, 1.5), options: scaleLevelsOptionsValue("round", nil, nil), want: `{"extreme":8,"high":7,"low":5,"medium":6}`},
+		{name: "clamped", base: 2, multipliers: scaleLevelsString("aggressive"), options: scaleLevelsOptionsValue("ceil", big.NewFloat(3), big.NewFloat(10)), want: `{"extreme":10,"high":8,"low":3,"medium":4}`},
+		{name: "clamped overflow", base: 1e300, multipliers: scaleLevelsString("flat"), options: scaleLevelsOptionsValue("ceil", nil, big.NewFloat(100)), want: `{"extreme":100,"high":100,"low":100,"medium":100}`},
+		{name: "overflow", base: 1e19, multipliers: scaleLevelsString("flat"), options: types.DynamicNull(), wantErr: "does not fit in a 64-bit integer"},
+		{name: "infinite multiplier", base: 1, multipliers: scaleLevelsObject(map[string]*big.Float{"low": big.NewFloat(1), "medium": big.NewFloat(1), "high": big.NewFloat(1), "extreme": new(big.Float).SetMantExp(big.NewFloat(1), 2000)}), options: types.DynamicNull(), wantErr: "must fit in a 64-bit float"},
+		{name: "negative base", base: -1, multipliers: scaleLevelsString("flat"), options: types.DynamicNull(), wantErr: "base must not be negative"},
+		{name: "unknown profile", base: 1, multipliers: scaleLevelsString("gentle"), options: types.DynamicNull(), wantErr: `unknown profile "gentle"`},
+		{name: "fractional min", base: 1, multipliers: scaleLevelsString("flat"), options: scaleLevelsOptionsValue("ceil", big.NewFloat(1.5), nil), wantErr: "min must be a whole number"},
+		{name: "out of range max", base: 1, multipliers: scaleLevelsString("flat"), options: scaleLevelsOptionsValue("ceil", nil, big.NewFloat(1e19)), wantErr: "max must fit in a 64-bit integer"},
+		{name: "min above max", base: 1, multipliers: scaleLevelsString("flat"), options: scaleLevelsOptionsValue("ceil", big.NewFloat(5), big.NewFloat(2)), wantErr: "min (5) must not be greater than max (2)"},
+		{name: "unknown rounding", base: 1, multipliers: scaleLevelsString("flat"), options: scaleLevelsOptionsValue("up", nil, nil), wantErr: "rounding must be one of"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := NewScaleLevelsFunction()
+			var definition function.DefinitionResponse
+			f.Definition(ctx, function.DefinitionRequest{}, &definition)
+			result, funcErr := definition.Definition.Return.NewResultData(ctx)
+			if funcErr != nil {
+				t.Fatal(funcErr)
+			}
+			resp := function.RunResponse{Result: result}
+			f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.Float64Value(tt.base), tt.multipliers, tt.options})}, &resp)
+			if tt.wantErr != "" {
+				if resp.Error == nil || !strings.Contains(resp.Error.Error(), tt.wantErr) {
+					t.Fatalf("Run() error = %v, want error containing %q", resp.Error, tt.wantErr)
+				}
+				return
+			}
+			if resp.Error != nil {
+				t.Fatalf("Run() returned error: %s", resp.Error)
+			}
+			if got := resp.Result.Value().String(); got != tt.want {
+				t.Fatalf("Run() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func scaleLevelsString(profile string) types.Dynamic {
+	return types.DynamicValue(types.StringValue(profile))
+}
+
+func scaleLevelsMultipliersValue(low, medium, high, extreme float64) types.Dynamic {
+	return scaleLevelsObject(map[string]*big.Float{
+		"low":     big.NewFloat(low),
+		"medium":  big.NewFloat(medium),
+		"high":    big.NewFloat(high),
+		"extreme": big.NewFloat(extreme),
+	})
+}
+
+// scaleLevelsObject returns an object of numbers as a dynamic argument.
+func scaleLevelsObject(values map[string]*big.Float) types.Dynamic {
+	attributeTypes := map[string]attr.Type{}
+	attributes := map[string]attr.Value{}
+	for name, value := range values {
+		attributeTypes[name] = types.NumberType
+		attributes[name] = types.NumberValue(value)
+	}
+	return types.DynamicValue(types.ObjectValueMust(attributeTypes, attributes))
+}
+
+func scaleLevelsOptionsValue(rounding string, minimum, maximum *big.Float) types.Dynamic {
+	return types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"rounding": types.StringType, "min": types.NumberType, "max": types.NumberType},
+		map[string]attr.Value{"rounding": types.StringValue(rounding), "min": types.NumberValue(minimum), "max": types.NumberValue(maximum)},
+	))
+}
@@ -0,0 +1,160 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"terraform-provider-sss/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action                   = &setLevelAction{}
+	_ action.ActionWithConfigure      = &setLevelAction{}
+	_ action.ActionWithValidateConfig = &setLevelAction{}
+)
+
+// NewSetLevelAction is a helper function to simplify the provider implementation.
+func NewSetLevelAction() action.Action {
+	return &setLevelAction{}
+}
+
+// setLevelAction is the action implementation.
+type setLevelAction struct {
+	client *client.SssClient
+}
+
+// setLevelActionModel maps the action schema data.
+type setLevelActionModel struct {
+	levelOverrideTargetModel
+	Level    types.String `tfsdk:"level"`
+	Duration types.String `tfsdk:"duration"`
+}
+
+func (a *setLevelAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.SssClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Action Configure Type", fmt.Sprintf("Expected *client.SssClient, got: %T. Please report this issue to the provider developers.", req.ProviderData))
+		return
+	}
+	a.client = c
+}
+
+// Metadata returns the action type name.
+func (a *setLevelAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_set_level"
+}
+
+// Schema defines the schema for the action.
+func (a *setLevelAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	attributes := levelOverrideTargetAttributes()
+	attributes["level"] = schema.StringAttribute{
+		MarkdownDescription: "The level to switch to, one of `" + strings.Join(scaleLevelNames, "`, `") + "`.",
+		Required:            true,
+	}
+	attributes["duration"] = schema.StringAttribute{
+		MarkdownDescription: "How long the override lasts before the schedule takes over again, as a Go duration such as `30m` or `2h`. When omitted the override lasts until it is cleared with `sss_clear_override`.",
+		Optional:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Switches a scalable, or every scalable in a group, to a level immediately, overriding its schedule.",
+		Attributes:          attributes,
+	}
+}
+
+// ValidateConfig validates the target, level and duration.
+func (a *setLevelAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var config setLevelActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(config.validate()...)
+
+	if !config.Level.IsUnknown() && !slices.Contains(scaleLevelNames, config.Level.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("level"),
+			"Invalid Level",
+			fmt.Sprintf("level must be one of %s, got %q.", strings.Join(scaleLevelNames, ", "), config.Level.ValueString()),
+		)
+	}
+
+	if !config.Duration.IsNull() && !config.Duration.IsUnknown() {
+		if _, err := parseDurationSeconds("duration", config.Duration.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("duration"), "Invalid Duration", err.Error())
+		}
+	}
+}
+
+// Invoke sends the override to SSS.
+func (a *setLevelAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var config setLevelActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	override := client.LevelOverridePostBody{
+		Level:  config.Level.ValueString(),
+		Region: config.Region.ValueString(),
+	}
+	message := fmt.Sprintf("Switching %s to level %s", config.description(), override.Level)
+	if !config.Duration.IsNull() {
+		duration, err := parseDurationSeconds("duration", config.Duration.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("duration"), "Invalid Duration", err.Error())
+			return
+		}
+		override.DurationSeconds = int64(duration / time.Second)
+		message += " for " + duration.String()
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: message})
+
+	c := a.client.ForRegion(config.Region.ValueString())
+	var err error
+	if !config.Group.IsNull() {
+		err = c.SetGroupLevel(ctx, config.Group.ValueString(), override)
+	} else {
+		err = c.SetScalableLevel(ctx, client.ScalableType(config.ScalableType.ValueString()), config.ScalableID.ValueString(), override)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to set level", err.Error())
+		return
+	}
+
+	// In a dry run the override is only logged, so SSS has not switched.
+	if a.client.DryRun() {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Dry run, logged the switch of %s to level %s without sending it to SSS", config.description(), override.Level)})
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Switched %s to level %s", config.description(), override.Level)})
+}
+
+// parseDurationSeconds parses the value of a duration attribute, which must
+// be a positive whole number of seconds.
+func parseDurationSeconds(attribute string, s string) (time.Duration, error) {
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a duration such as 30m or 2h: %w", attribute, err)
+	}
+	if duration < time.Second || duration%time.Second != 0 {
+		return 0, fmt.Errorf("%s must be a positive whole number of seconds, got %s", attribute, s)
+	}
+	return duration, nil
+}
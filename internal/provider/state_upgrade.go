@@ -0,0 +1,89 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// scalableSchemaVersion is the schema version of all scaling resources.
+// Bump it, and add an upgrader to scalableStateUpgraders, when a change to
+// the resources would not read state written by earlier versions.
+const scalableSchemaVersion = 1
+
+// scalableIDAttribute returns the scalable_id attribute shared by all scaling
+// resources.
+func scalableIDAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "The SSS scalable ID used as the URL path component, the same for all scalable types.",
+		Computed:    true,
+	}
+}
+
+// modifyPlanScalableID plans scalable_id as the value of the ID attribute of
+// the resource.
+func modifyPlanScalableID(ctx context.Context, idPath path.Path, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var scalableID types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, idPath, &scalableID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("scalable_id"), scalableID)...)
+}
+
+// scalableStateUpgraders returns the state upgraders shared by all scaling
+// resources, keyed by the schema version they upgrade from. idAttribute is
+// the name of the attribute holding the scalable ID, e.g. service_id, and
+// normalizeV0ID, when not nil, converts a version 0 value of it to a
+// scalable ID.
+func scalableStateUpgraders(idAttribute string, normalizeV0ID func(id string) string) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 state was written by several releases with different
+		// attributes, so it is read as raw JSON rather than with a prior
+		// schema. Attributes added since then are left null for the next
+		// Read to refresh.
+		0: {
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var state map[string]any
+				if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+					resp.Diagnostics.AddError("Failed to upgrade state", "Could not parse the version 0 state: "+err.Error())
+					return
+				}
+				if id, ok := state[idAttribute].(string); ok && normalizeV0ID != nil {
+					state[idAttribute] = normalizeV0ID(id)
+				}
+				state["scalable_id"] = state[idAttribute]
+
+				upgraded, err := json.Marshal(state)
+				if err != nil {
+					resp.Diagnostics.AddError("Failed to upgrade state", "Could not encode the version 1 state: "+err.Error())
+					return
+				}
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+			},
+		},
+	}
+}
+
+// dynamoTableIDFromV0 converts a version 0 table_name, which was documented
+// as the ARN of the table, to the table/TABLE_NAME scalable ID. Values that
+// are not DynamoDB table ARNs are kept.
+func dynamoTableIDFromV0(tableName string) string {
+	if parsed, err := parseDynamoTableArn(tableName); err == nil {
+		return parsed.ID
+	}
+	return tableName
+}
@@ -0,0 +1,76 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestUpgradeStateV0(t *testing.T) {
+	// The states are written by the 1.2 releases, with schema version 0.
+	const dynamoCapacity = `{"min_write":1,"max_write":10,"min_read":2,"max_read":20}`
+	tests := []struct {
+		name     string
+		typeName string
+		state    string
+		want     map[string]tftypes.Value
+	}{
+		{
+			name:     "ecs",
+			typeName: "sss_ecs_scaling",
+			state:    `{"service_id":"service/cluster/app","region":"eu-west-1","last_updated":"Monday, 19-Oct-26 08:00:00 UTC","min_tasks":{"low":1,"medium":2,"high":3,"extreme":4}}`,
+			want: map[string]tftypes.Value{
+				"service_id":   tftypes.NewValue(tftypes.String, "service/cluster/app"),
+				"scalable_id":  tftypes.NewValue(tftypes.String, "service/cluster/app"),
+				"last_updated": tftypes.NewValue(tftypes.String, "Monday, 19-Oct-26 08:00:00 UTC"),
+				"tags":         tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+			},
+		},
+		{
+			name:     "dynamo table name",
+			typeName: "sss_dynamo_table_scaling",
+			state:    `{"table_name":"table/entries","region":"eu-west-1","last_updated":"Monday, 19-Oct-26 08:00:00 UTC","capacity":{"low":` + dynamoCapacity + `,"medium":` + dynamoCapacity + `,"high":` + dynamoCapacity + `,"extreme":` + dynamoCapacity + `}}`,
+			want: map[string]tftypes.Value{
+				"table_name":  tftypes.NewValue(tftypes.String, "table/entries"),
+				"scalable_id": tftypes.NewValue(tftypes.String, "table/entries"),
+			},
+		},
+		{
+			name:     "dynamo table ARN",
+			typeName: "sss_dynamo_table_scaling",
+			state:    `{"table_name":"arn:aws:dynamodb:eu-west-1:123456789012:table/entries","region":"eu-west-1","last_updated":null,"capacity":{"low":` + dynamoCapacity + `,"medium":` + dynamoCapacity + `,"high":` + dynamoCapacity + `,"extreme":` + dynamoCapacity + `}}`,
+			want: map[string]tftypes.Value{
+				"table_name":  tftypes.NewValue(tftypes.String, "table/entries"),
+				"scalable_id": tftypes.NewValue(tftypes.String, "table/entries"),
+				"region":      tftypes.NewValue(tftypes.String, "eu-west-1"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sss := newFakeSSS(t)
+			p := newTestProvider(t, sss.host(), nil)
+			resp, err := p.server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+				TypeName: tt.typeName,
+				Version:  0,
+				RawState: &tfprotov6.RawState{JSON: []byte(tt.state)},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			requireNoErrors(t, "UpgradeResourceState", resp.Diagnostics)
+
+			values := p.value(tt.typeName, resp.UpgradedState)
+			for name, want := range tt.want {
+				if !values[name].Equal(want) {
+					t.Errorf("%s = %s, want %s", name, values[name], want)
+				}
+			}
+		})
+	}
+}
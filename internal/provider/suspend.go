@@ -0,0 +1,182 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// suspendModel holds the suspend attributes shared by all scaling resources.
+type suspendModel struct {
+	Suspended      types.Bool   `tfsdk:"suspended"`
+	SuspendedUntil types.String `tfsdk:"suspended_until"`
+}
+
+// suspendAttributes returns the suspend attributes shared by all scaling
+// resources.
+func suspendAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"suspended": schema.BoolAttribute{
+			Description: "Whether SSS should stop scaling the scalable while keeping its registration. Defaults to false.",
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(false),
+		},
+		"suspended_until": schema.StringAttribute{
+			Description: "When SSS should resume scaling a suspended scalable, in RFC 3339 format. Requires suspended to be true and must be in the future when it is set or changed. " +
+				"Once the time has passed, SSS resumes scaling, the resource reads the resumed scalable back into state, " +
+				"and plans warn, without suspending the scalable again, until suspended and suspended_until are removed from the configuration or a future time is set.",
+			Optional: true,
+			Validators: []validator.String{
+				suspendedUntilValidator{},
+			},
+		},
+	}
+}
+
+// suspendedUntilPrivateKey is the private state key holding the
+// suspended_until last sent to SSS.
+const suspendedUntilPrivateKey = "suspended_until"
+
+// now returns the current time. Tests replace it to let suspended_until pass.
+var now = time.Now
+
+// toClientModel returns the suspended flag and time to send to SSS. A
+// suspended_until that has passed is not sent, as SSS has already resumed
+// the scalable and would otherwise suspend it again.
+func (m suspendModel) toClientModel() (bool, *time.Time) {
+	if m.SuspendedUntil.IsNull() || m.SuspendedUntil.IsUnknown() {
+		return m.Suspended.ValueBool(), nil
+	}
+	// The validator has already rejected values that do not parse.
+	until, _ := time.Parse(time.RFC3339, m.SuspendedUntil.ValueString())
+	if !until.After(now()) {
+		return false, nil
+	}
+	return m.Suspended.ValueBool(), &until
+}
+
+// newSuspendModel converts the suspend fields of an SSS registration to the
+// suspend attributes. The prior suspended_until is kept when it denotes the
+// same time, so a configured time zone offset does not show as a change.
+func newSuspendModel(suspended bool, suspendedUntil *time.Time, prior suspendModel) suspendModel {
+	m := suspendModel{
+		Suspended:      types.BoolValue(suspended),
+		SuspendedUntil: types.StringNull(),
+	}
+	if suspendedUntil == nil {
+		return m
+	}
+	if priorUntil, err := time.Parse(time.RFC3339, prior.SuspendedUntil.ValueString()); err == nil && priorUntil.Equal(*suspendedUntil) {
+		m.SuspendedUntil = prior.SuspendedUntil
+		return m
+	}
+	m.SuspendedUntil = types.StringValue(suspendedUntil.UTC().Format(time.RFC3339))
+	return m
+}
+
+// setSuspendedUntilPrivate records the applied suspended_until in private
+// state, so later plans can tell a time that has since passed from a past
+// time that is newly configured.
+func setSuspendedUntilPrivate(ctx context.Context, private privateState, m suspendModel) diag.Diagnostics {
+	if m.SuspendedUntil.IsNull() || m.SuspendedUntil.IsUnknown() {
+		return private.SetKey(ctx, suspendedUntilPrivateKey, nil)
+	}
+	value, err := json.Marshal(m.SuspendedUntil.ValueString())
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Failed to record suspended_until", err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, suspendedUntilPrivateKey, value)
+}
+
+// privateState is the private state of a resource.
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// modifyPlanSuspendedUntil requires a configured suspended_until to be in the
+// future when it is set or changed. A suspended_until that was applied and
+// has since passed only gets a warning, as SSS has resumed the scalable and
+// the configuration is otherwise still valid.
+func modifyPlanSuspendedUntil(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("suspended_until"), &configured)...)
+	if configured.IsNull() || configured.IsUnknown() {
+		return
+	}
+	until, err := time.Parse(time.RFC3339, configured.ValueString())
+	if err != nil || until.After(now()) {
+		return
+	}
+
+	applied := []string{}
+	if !req.State.Raw.IsNull() {
+		var prior types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("suspended_until"), &prior)...)
+		applied = append(applied, prior.ValueString())
+	}
+	if value, diags := req.Private.GetKey(ctx, suspendedUntilPrivateKey); len(value) > 0 && !diags.HasError() {
+		var recorded string
+		if json.Unmarshal(value, &recorded) == nil {
+			applied = append(applied, recorded)
+		}
+	}
+	for _, value := range applied {
+		if appliedUntil, err := time.Parse(time.RFC3339, value); err == nil && appliedUntil.Equal(until) {
+			resp.Diagnostics.AddAttributeWarning(path.Root("suspended_until"), "suspended_until has passed",
+				"suspended_until "+configured.ValueString()+" has passed, so SSS has resumed scaling the scalable and the provider does not suspend it again. "+
+					"Remove suspended and suspended_until from the configuration, or set a future time to suspend it again.")
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(path.Root("suspended_until"), "Invalid suspended_until",
+		"suspended_until "+configured.ValueString()+" has passed. Set a future time to suspend the scalable.")
+}
+
+// suspendedUntilValidator validates that suspended_until is an RFC 3339 time
+// and is only set on suspended scalables. Whether the time is in the future
+// is checked when planning, see modifyPlanSuspendedUntil.
+type suspendedUntilValidator struct{}
+
+func (v suspendedUntilValidator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 time and suspended must be true"
+}
+
+func (v suspendedUntilValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v suspendedUntilValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid suspended_until", "suspended_until must be a time in RFC 3339 format, e.g. 2025-11-01T18:00:00Z: "+err.Error())
+		return
+	}
+
+	var suspended types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("suspended"), &suspended)...)
+	if !suspended.IsUnknown() && !suspended.ValueBool() {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid suspended_until", "suspended_until can only be set when suspended is true.")
+	}
+}
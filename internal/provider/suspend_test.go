@@ -0,0 +1,121 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestNewSuspendModel(t *testing.T) {
+	until := time.Date(2026, 11, 1, 17, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		suspended      bool
+		suspendedUntil *time.Time
+		prior          suspendModel
+		want           suspendModel
+	}{
+		{
+			name:           "suspended",
+			suspended:      true,
+			suspendedUntil: &until,
+			prior:          suspendModel{Suspended: types.BoolValue(true), SuspendedUntil: types.StringNull()},
+			want:           suspendModel{Suspended: types.BoolValue(true), SuspendedUntil: types.StringValue("2026-11-01T17:00:00Z")},
+		},
+		{
+			name:           "same time with offset",
+			suspended:      true,
+			suspendedUntil: &until,
+			prior:          suspendModel{Suspended: types.BoolValue(true), SuspendedUntil: types.StringValue("2026-11-01T18:00:00+01:00")},
+			want:           suspendModel{Suspended: types.BoolValue(true), SuspendedUntil: types.StringValue("2026-11-01T18:00:00+01:00")},
+		},
+		{
+			name:  "resumed",
+			prior: suspendModel{Suspended: types.BoolValue(true), SuspendedUntil: types.StringValue("2020-01-01T00:00:00Z")},
+			want:  suspendModel{Suspended: types.BoolValue(false), SuspendedUntil: types.StringNull()},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newSuspendModel(tt.suspended, tt.suspendedUntil, tt.prior); got != tt.want {
+				t.Fatalf("newSuspendModel() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanSuspendedUntil(t *testing.T) {
+	setNow(t, time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	suspendedConfig := func(p *testProvider, until string) tftypes.Value {
+		return ecsConfig(p, map[string]tftypes.Value{
+			"suspended":       tftypes.NewValue(tftypes.Bool, true),
+			"suspended_until": tftypes.NewValue(tftypes.String, until),
+		})
+	}
+
+	t.Run("new past time", func(t *testing.T) {
+		sss := newFakeSSS(t)
+		p := newTestProvider(t, sss.host(), nil)
+		state := p.create("sss_ecs_scaling", ecsConfig(p, nil))
+
+		plan := p.plan("sss_ecs_scaling", state, suspendedConfig(p, "2026-10-19T09:00:00Z"))
+		requireDiagnostic(t, plan.Diagnostics, tfprotov6.DiagnosticSeverityError, "has passed")
+	})
+
+	t.Run("applied time that has passed", func(t *testing.T) {
+		sss := newFakeSSS(t)
+		p := newTestProvider(t, sss.host(), nil)
+		config := suspendedConfig(p, "2026-10-19T12:00:00Z")
+		state := p.create("sss_ecs_scaling", config)
+
+		// SSS resumes the scalable once suspended_until has passed.
+		setNow(t, time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC))
+		sss.update("/api/v1/services/ecs/service/cluster/app", func(registration map[string]any) {
+			registration["suspended"] = false
+			delete(registration, "suspendedUntil")
+		})
+
+		state, diags := p.read("sss_ecs_scaling", state)
+		requireNoErrors(t, "ReadResource", diags)
+		values := p.value("sss_ecs_scaling", state.state)
+		if !values["suspended"].Equal(tftypes.NewValue(tftypes.Bool, false)) || !values["suspended_until"].IsNull() {
+			t.Fatalf("state after resume: suspended = %s, suspended_until = %s, want false and null", values["suspended"], values["suspended_until"])
+		}
+
+		plan := p.plan("sss_ecs_scaling", state, config)
+		requireNoErrors(t, "PlanResourceChange", plan.Diagnostics)
+		requireDiagnostic(t, plan.Diagnostics, tfprotov6.DiagnosticSeverityWarning, "has passed")
+
+		_, diags = p.apply("sss_ecs_scaling", state, config, plan)
+		requireNoErrors(t, "ApplyResourceChange", diags)
+		registration := sss.registration("/api/v1/services/ecs/service/cluster/app")
+		if registration["suspended"] != false || registration["suspendedUntil"] != nil {
+			t.Fatalf("registration after apply: suspended = %v, suspendedUntil = %v, want false and none", registration["suspended"], registration["suspendedUntil"])
+		}
+	})
+}
+
+// setNow sets the time the suspend checks compare against for the test.
+func setNow(t *testing.T, current time.Time) {
+	t.Helper()
+	previous := now
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = previous })
+}
+
+func requireDiagnostic(t *testing.T, diags []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity, detail string) {
+	t.Helper()
+	for _, d := range diags {
+		if d.Severity == severity && strings.Contains(d.Detail, detail) {
+			return
+		}
+	}
+	t.Fatalf("diagnostics %v do not contain a %s containing %q", diags, severity, detail)
+}
@@ -0,0 +1,166 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ownershipModel holds the ownership attributes shared by all scaling
+// resources.
+type ownershipModel struct {
+	Tags    types.Map    `tfsdk:"tags"`
+	TagsAll types.Map    `tfsdk:"tags_all"`
+	Owner   types.String `tfsdk:"owner"`
+	Contact types.String `tfsdk:"contact"`
+}
+
+// ownershipAttributes returns the ownership attributes shared by all scaling
+// resources.
+func ownershipAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"tags": schema.MapAttribute{
+			Description: "Tags to register with the scalable. Tags with the same key as a provider default_tags tag take precedence.",
+			ElementType: types.StringType,
+			Optional:    true,
+		},
+		"tags_all": schema.MapAttribute{
+			Description: "The tags registered with the scalable, including those inherited from the provider default_tags.",
+			ElementType: types.StringType,
+			Computed:    true,
+		},
+		"owner": schema.StringAttribute{
+			Description: "The team that owns the scalable.",
+			Optional:    true,
+		},
+		"contact": schema.StringAttribute{
+			Description: "How to reach the owner of the scalable, e.g. a Slack channel or an email address.",
+			Optional:    true,
+		},
+	}
+}
+
+// toClientModel returns the tags, owner and contact to send to SSS. The tags
+// are taken from tags_all, which ModifyPlan or resolveTagsAll has merged with
+// the default tags.
+func (m ownershipModel) toClientModel() (map[string]string, string, string) {
+	tags := map[string]string{}
+	for key, value := range m.TagsAll.Elements() {
+		if value, ok := value.(types.String); ok {
+			tags[key] = value.ValueString()
+		}
+	}
+	return tags, m.Owner.ValueString(), m.Contact.ValueString()
+}
+
+// resolveTagsAll sets tags_all when it was planned as unknown because tags
+// or the default tags were not known yet, as they are during apply.
+func (m *ownershipModel) resolveTagsAll(defaultTags map[string]string) {
+	if !m.TagsAll.IsUnknown() {
+		return
+	}
+	if tagsAll, known := mergeTags(defaultTags, m.Tags); known {
+		m.TagsAll = stringMapValue(tagsAll)
+	}
+}
+
+// newOwnershipModel converts the ownership fields of an SSS registration to
+// the ownership attributes. Tags inherited unchanged from the default tags
+// are left out of tags, unless the prior tags set them explicitly. While the
+// default tags are not known, which is when defaultTags is nil, only the
+// prior tags are kept in tags.
+func newOwnershipModel(tags map[string]string, owner string, contact string, defaultTags map[string]string, prior types.Map) ownershipModel {
+	priorTags := prior.Elements()
+	resourceTags := map[string]string{}
+	for key, value := range tags {
+		defaultValue, inherited := defaultTags[key]
+		_, explicit := priorTags[key]
+		if explicit || (defaultTags != nil && (!inherited || defaultValue != value)) {
+			resourceTags[key] = value
+		}
+	}
+
+	m := ownershipModel{
+		Tags:    types.MapNull(types.StringType),
+		TagsAll: stringMapValue(tags),
+		Owner:   types.StringNull(),
+		Contact: types.StringNull(),
+	}
+	if len(resourceTags) > 0 || (!prior.IsNull() && !prior.IsUnknown()) {
+		m.Tags = stringMapValue(resourceTags)
+	}
+	if owner != "" {
+		m.Owner = types.StringValue(owner)
+	}
+	if contact != "" {
+		m.Contact = types.StringValue(contact)
+	}
+	return m
+}
+
+// modifyPlanTagsAll plans tags_all as the default tags merged with tags, or
+// as unknown while either is not known.
+func modifyPlanTagsAll(ctx context.Context, defaultTags map[string]string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tagsAll, known := mergeTags(defaultTags, tags)
+	if !known {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), stringMapValue(tagsAll))...)
+}
+
+// mergeTags returns the default tags merged with tags, which take precedence,
+// and false when the default tags, which are nil then, or tags are not known.
+func mergeTags(defaultTags map[string]string, tags types.Map) (map[string]string, bool) {
+	resourceTags, known := knownStringMap(tags)
+	if defaultTags == nil || !known {
+		return nil, false
+	}
+	merged := maps.Clone(defaultTags)
+	maps.Copy(merged, resourceTags)
+	return merged, true
+}
+
+// knownStringMap converts a map of strings to a Go map, and returns false
+// when the map or any of its elements is unknown. A null map is empty.
+func knownStringMap(m types.Map) (map[string]string, bool) {
+	if m.IsUnknown() {
+		return nil, false
+	}
+	elements := make(map[string]string, len(m.Elements()))
+	for key, value := range m.Elements() {
+		value, ok := value.(types.String)
+		if !ok || value.IsUnknown() {
+			return nil, false
+		}
+		elements[key] = value.ValueString()
+	}
+	return elements, true
+}
+
+// stringMapValue converts a Go string map to a Terraform map value.
+func stringMapValue(m map[string]string) types.Map {
+	elements := make(map[string]attr.Value, len(m))
+	for key, value := range m {
+		elements[key] = types.StringValue(value)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
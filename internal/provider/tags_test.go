@@ -0,0 +1,204 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultTags map[string]string
+		tags        types.Map
+		want        map[string]string
+		wantKnown   bool
+	}{
+		{
+			name:        "resource tags take precedence",
+			defaultTags: map[string]string{"team": "video", "env": "prod"},
+			tags:        stringMapValue(map[string]string{"team": "audio", "app": "api"}),
+			want:        map[string]string{"team": "audio", "env": "prod", "app": "api"},
+			wantKnown:   true,
+		},
+		{
+			name:        "no resource tags",
+			defaultTags: map[string]string{"team": "video"},
+			tags:        types.MapNull(types.StringType),
+			want:        map[string]string{"team": "video"},
+			wantKnown:   true,
+		},
+		{
+			name:        "unknown default tags",
+			defaultTags: nil,
+			tags:        stringMapValue(map[string]string{"app": "api"}),
+		},
+		{
+			name:        "unknown tags",
+			defaultTags: map[string]string{},
+			tags:        types.MapUnknown(types.StringType),
+		},
+		{
+			name:        "unknown tag",
+			defaultTags: map[string]string{},
+			tags:        types.MapValueMust(types.StringType, map[string]attr.Value{"app": types.StringUnknown()}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known := mergeTags(tt.defaultTags, tt.tags)
+			if known != tt.wantKnown || !maps.Equal(got, tt.want) {
+				t.Fatalf("mergeTags() = %v, %t, want %v, %t", got, known, tt.want, tt.wantKnown)
+			}
+		})
+	}
+}
+
+func TestNewOwnershipModel(t *testing.T) {
+	registered := map[string]string{"team": "video", "env": "prod", "app": "api"}
+	tests := []struct {
+		name        string
+		defaultTags map[string]string
+		prior       types.Map
+		want        types.Map
+	}{
+		{
+			name:        "inherited tags left out",
+			defaultTags: map[string]string{"team": "video", "env": "prod"},
+			prior:       types.MapNull(types.StringType),
+			want:        stringMapValue(map[string]string{"app": "api"}),
+		},
+		{
+			name:        "overridden default tag kept",
+			defaultTags: map[string]string{"team": "audio", "env": "prod"},
+			prior:       types.MapNull(types.StringType),
+			want:        stringMapValue(map[string]string{"team": "video", "app": "api"}),
+		},
+		{
+			name:        "explicit default tag kept",
+			defaultTags: map[string]string{"team": "video", "env": "prod"},
+			prior:       stringMapValue(map[string]string{"team": "video", "app": "api"}),
+			want:        stringMapValue(map[string]string{"team": "video", "app": "api"}),
+		},
+		{
+			name:        "only inherited tags",
+			defaultTags: map[string]string{"team": "video", "env": "prod", "app": "api"},
+			prior:       types.MapNull(types.StringType),
+			want:        types.MapNull(types.StringType),
+		},
+		{
+			name:        "unknown default tags",
+			defaultTags: nil,
+			prior:       stringMapValue(map[string]string{"app": "api"}),
+			want:        stringMapValue(map[string]string{"app": "api"}),
+		},
+		{
+			name:        "unknown default tags without prior tags",
+			defaultTags: nil,
+			prior:       types.MapNull(types.StringType),
+			want:        types.MapNull(types.StringType),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newOwnershipModel(registered, "", "", tt.defaultTags, tt.prior)
+			if !m.Tags.Equal(tt.want) {
+				t.Errorf("tags = %s, want %s", m.Tags, tt.want)
+			}
+			if want := stringMapValue(registered); !m.TagsAll.Equal(want) {
+				t.Errorf("tags_all = %s, want %s", m.TagsAll, want)
+			}
+		})
+	}
+}
+
+func TestDefaultTags(t *testing.T) {
+	const apiPath = "/api/v1/services/ecs/service/cluster/app"
+	tagsType := tftypes.Map{ElementType: tftypes.String}
+	defaultTags := func(tags map[string]tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"default_tags": tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{"tags": tagsType}}, map[string]tftypes.Value{
+				"tags": tftypes.NewValue(tagsType, tags),
+			}),
+		}
+	}
+	resourceTags := map[string]tftypes.Value{"tags": tftypes.NewValue(tagsType, map[string]tftypes.Value{
+		"app": tftypes.NewValue(tftypes.String, "api"),
+	})}
+
+	t.Run("unknown default tags", func(t *testing.T) {
+		sss := newFakeSSS(t)
+		p := newTestProvider(t, sss.host(), defaultTags(map[string]tftypes.Value{
+			"team": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}))
+		config := ecsConfig(p, resourceTags)
+		plan := p.plan("sss_ecs_scaling", nil, config)
+		requireNoErrors(t, "PlanResourceChange", plan.Diagnostics)
+		if tagsAll := p.value("sss_ecs_scaling", plan.PlannedState)["tags_all"]; tagsAll.IsKnown() {
+			t.Fatalf("planned tags_all = %s, want unknown", tagsAll)
+		}
+
+		// Terraform configures the provider again with the known default
+		// tags for the apply.
+		p = newTestProvider(t, sss.host(), defaultTags(map[string]tftypes.Value{
+			"team": tftypes.NewValue(tftypes.String, "video"),
+		}))
+		state, diags := p.apply("sss_ecs_scaling", nil, config, plan)
+		requireNoErrors(t, "ApplyResourceChange", diags)
+		want := tftypes.NewValue(tagsType, map[string]tftypes.Value{
+			"team": tftypes.NewValue(tftypes.String, "video"),
+			"app":  tftypes.NewValue(tftypes.String, "api"),
+		})
+		if tagsAll := p.value("sss_ecs_scaling", state.state)["tags_all"]; !tagsAll.Equal(want) {
+			t.Fatalf("tags_all = %s, want %s", tagsAll, want)
+		}
+		if tags, _ := sss.registration(apiPath)["tags"].(map[string]any); len(tags) != 2 {
+			t.Fatalf("registered tags = %v, want team and app", tags)
+		}
+	})
+
+	t.Run("moved restapi_object", func(t *testing.T) {
+		sss := newFakeSSS(t)
+		p := newTestProvider(t, sss.host(), defaultTags(map[string]tftypes.Value{
+			"team": tftypes.NewValue(tftypes.String, "video"),
+		}))
+		config := ecsConfig(p, resourceTags)
+		p.create("sss_ecs_scaling", config)
+
+		moved, err := p.server.MoveResourceState(context.Background(), &tfprotov6.MoveResourceStateRequest{
+			SourceProviderAddress: "registry.terraform.io/mastercard/restapi",
+			SourceTypeName:        "restapi_object",
+			SourceState: &tfprotov6.RawState{JSON: []byte(`{"id":"service/cluster/app","path":"/api/v1/services/ecs","data":"{}",` +
+				`"api_response":"{\"name\":\"service/cluster/app\",\"region\":\"eu-west-1\",\"minLowCapacity\":1,\"minMediumCapacity\":2,\"minHighCapacity\":3,\"minExtremeCapacity\":4,\"tags\":{\"team\":\"video\",\"app\":\"api\"}}"}`)},
+			TargetTypeName: "sss_ecs_scaling",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		requireNoErrors(t, "MoveResourceState", moved.Diagnostics)
+
+		state, diags := p.read("sss_ecs_scaling", &testResourceState{state: moved.TargetState, identity: moved.TargetIdentity})
+		requireNoErrors(t, "ReadResource", diags)
+		want := tftypes.NewValue(tagsType, map[string]tftypes.Value{
+			"app": tftypes.NewValue(tftypes.String, "api"),
+		})
+		if tags := p.value("sss_ecs_scaling", state.state)["tags"]; !tags.Equal(want) {
+			t.Fatalf("tags = %s, want %s", tags, want)
+		}
+
+		plan := p.plan("sss_ecs_scaling", state, config)
+		requireNoErrors(t, "PlanResourceChange", plan.Diagnostics)
+		planned, current := p.value("sss_ecs_scaling", plan.PlannedState)["tags_all"], p.value("sss_ecs_scaling", state.state)["tags_all"]
+		if !planned.Equal(current) {
+			t.Fatalf("planned tags_all = %s, want %s", planned, current)
+		}
+	})
+}
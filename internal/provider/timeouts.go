@@ -0,0 +1,52 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultScalableTimeout is used for every scaling resource operation without
+// a configured timeout.
+const defaultScalableTimeout = 5 * time.Minute
+
+// scalableTimeoutsBlock returns the timeouts block shared by all scaling resources.
+func scalableTimeoutsBlock(ctx context.Context) schema.Block {
+	return timeouts.Block(ctx, timeouts.Opts{
+		Create: true,
+		Read:   true,
+		Update: true,
+		Delete: true,
+	})
+}
+
+// nullScalableTimeouts returns an unset timeouts block, for models built
+// from SSS responses rather than from configuration.
+func nullScalableTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// clientErrorDetail returns the diagnostic detail for a failed SSS request,
+// pointing at the timeouts block when the operation timed out.
+func clientErrorDetail(err error, operation string) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Sprintf("SSS did not respond before the %s timeout expired: %s. The timeout can be raised with the %s argument of the resource's timeouts block.", operation, err, operation)
+	}
+	return err.Error()
+}
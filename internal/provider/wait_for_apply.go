@@ -0,0 +1,133 @@
+// Copyright (c) TV4 Media AB
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"terraform-provider-sss/internal/client"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultWaitForApplyPollInterval is how often the status of a scalable is
+// polled when wait_for_apply has no poll_interval.
+const defaultWaitForApplyPollInterval = 10 * time.Second
+
+// waitForApplyModel describes the wait_for_apply attribute shared by all scaling resources.
+type waitForApplyModel struct {
+	Timeout      types.String `tfsdk:"timeout"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+}
+
+// waitForApplySchema returns the wait_for_apply attribute shared by all scaling resources.
+func waitForApplySchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "When set, create and update wait until SSS has applied the registered capacity for the current level, polling the status of the scalable.",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait, as a Go duration such as `5m`. Defaults to the rest of the `create` or `update` timeout.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationSecondsValidator{},
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "How often to poll the status of the scalable, as a Go duration such as `30s`. Defaults to `10s`.",
+				Optional:            true,
+				Validators: []validator.String{
+					durationSecondsValidator{},
+				},
+			},
+		},
+	}
+}
+
+// wait polls the status of a scalable until applied reports that SSS has
+// applied the registered capacity for the current level. A scalable SSS has
+// no status for yet, such as one just registered, is polled again. It returns
+// immediately when wait_for_apply is not configured, or in a dry run, where
+// SSS has nothing to apply.
+func (m *waitForApplyModel) wait(ctx context.Context, c *client.SssClient, scalableType client.ScalableType, scalableID string, applied func(*client.ScalableStatusResponse) bool) error {
+	if m == nil || c.DryRun() {
+		return nil
+	}
+
+	pollInterval := defaultWaitForApplyPollInterval
+	if !m.PollInterval.IsNull() {
+		var err error
+		pollInterval, err = parseDurationSeconds("poll_interval", m.PollInterval.ValueString())
+		if err != nil {
+			return err
+		}
+	}
+	if !m.Timeout.IsNull() {
+		timeout, err := parseDurationSeconds("timeout", m.Timeout.ValueString())
+		if err != nil {
+			return err
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	level := ""
+	timedOut := func() error {
+		if level == "" {
+			return fmt.Errorf("timed out waiting for SSS to apply %s/%s: %w", scalableType, scalableID, ctx.Err())
+		}
+		return fmt.Errorf("timed out waiting for SSS to apply level %s to %s/%s: %w", level, scalableType, scalableID, ctx.Err())
+	}
+	for {
+		status, err := c.GetScalableStatus(ctx, scalableType, scalableID)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return timedOut()
+		case errors.Is(err, client.ErrNotFound):
+			// SSS has no status for the scalable yet.
+		case err != nil:
+			return err
+		case status.LastApplyStatus == client.ApplyStatusFailed:
+			return fmt.Errorf("SSS failed to apply level %s to %s/%s", status.CurrentLevel, scalableType, scalableID)
+		case applied(status):
+			return nil
+		default:
+			level = status.CurrentLevel
+		}
+
+		select {
+		case <-ctx.Done():
+			return timedOut()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// durationSecondsValidator validates that a duration parses with
+// parseDurationSeconds, so a typo fails the plan rather than a half-applied
+// create or update.
+type durationSecondsValidator struct{}
+
+func (v durationSecondsValidator) Description(_ context.Context) string {
+	return "value must be a positive Go duration of whole seconds, such as 30s or 5m"
+}
+
+func (v durationSecondsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationSecondsValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parseDurationSeconds(req.Path.String(), req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Duration", err.Error())
+	}
+}
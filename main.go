@@ -7,7 +7,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"os"
 
+	"terraform-provider-sss/internal/cli"
 	"terraform-provider-sss/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -23,6 +25,14 @@ var (
 )
 
 func main() {
+	// Companion subcommands such as "export" talk to SSS directly. Terraform
+	// never passes positional arguments when it starts the plugin.
+	if len(os.Args) > 1 {
+		if command, ok := cli.Commands[os.Args[1]]; ok {
+			os.Exit(command(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	var debug bool
 
 	flag.BoolVar(&debug, "debug", false, "set to true to run the provider with support for debuggers like delve")